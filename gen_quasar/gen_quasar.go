@@ -1,15 +1,29 @@
 package main
 
 import (
-	_ "embed"
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
+	"time"
 	"unicode"
 )
 
@@ -20,28 +34,56 @@ QUASAR CRUD GENERATOR
 Reads the consolidated schema (schema.logical.json) produced by the schema parser
 and generates a production-ready Quasar CRUD UI scaffold:
 
-  Per-entity:  IndexPage.vue, FormDialog.vue, DetailPage.vue, use{Entity}.ts
-  Shared:      SubTableCrud.vue, PivotSelect.vue
+  Per-entity:  IndexPage.vue, FormDialog.vue, DetailPage.vue, {Entity}.ts interface,
+               use{Entity}.ts composable (or use{Entity}Store.ts with -state pinia)
+  Shared:      SubTableCrud.vue, PivotSelect.vue, useConfirm.ts, export.ts
   Global:      API client, router, validation utils, Hydra/IRI helpers,
-               Zod-to-Quasar bridge, Orval config (dual vue-query + zod)
+               Zod-to-Quasar bridge, Orval config (dual vue-query + zod,
+               plus MSW mocks with -mock; -orval-client swaps vue-query)
 
 Template engine: Go text/template with [[ ]] delimiters to avoid Vue {{ }} conflict.
-All templates are embedded as string constants for single-binary portability.
+Templates live in templates/*.tmpl and are embedded via go:embed for
+single-binary portability.
+
+CONFIG FILE: -config quasar-gen.yaml (or .json) sets any flag by its name, e.g.
+  api-base: /api/v1
+  state: pinia
+  retries: 5
+Flags given on the command line override the file; unknown keys are an error.
 
 OUTPUT STRUCTURE:
   src-gen/
-    api/client.ts
+    api/client.ts                     axios instance, envelope unwrap, setupNotify (-notify)
+    api/{entity}.operations.ts        Method/path/operationId constants (entities with operations)
     components/SubTableCrud.vue       Reusable 1:N sub-table with inline CRUD
     components/PivotSelect.vue        Reusable M2M chip-based multi-select
-    composables/use{Entity}.ts
+    components/AppNavMenu.vue         Drawer menu linking every entity list
+    composables/useConfirm.ts         Shared delete and discard-changes confirmation prompts
+    composables/index.ts              Barrel of every use{Entity} (or use{Entity}Store), rules, useAuth, usePermissions
+    composables/use{Entity}.ts        (-state vue-query, default)
+    stores/use{Entity}Store.ts        (-state pinia)
+    composables/{entity}Rules.ts      Per-field Quasar rules (omitted with -inline-rules)
+    composables/useAuth.ts            Token login/logout (-auth)
+    composables/usePermissions.ts     can(permission) for action buttons, allow-all until setPermissions (-rbac)
+    pages/LoginPage.vue               (-auth)
+    router/guard.ts                   authGuard for router.beforeEach (-auth)
     pages/{entity}/IndexPage.vue
     pages/{entity}/FormDialog.vue
     pages/{entity}/DetailPage.vue
+    types/{Entity}.ts
     router/generated-routes.ts
     utils/validation.ts
     utils/hydra.ts
+    utils/export.ts                   CSV/JSON export of grid rows
+    utils/display.ts                  Shared display helpers (isImageUrl, formatNested, truncate)
+    utils/format.ts                   Display formatters (formatCurrency, formatDate, formatDateTime),
+                                      toLocal/toUTC between server UTC and local (-timezone) time
     utils/zod-to-quasar.ts
-    orval.config.ts
+    i18n/{entity}.en.ts               (-i18n) flat vue-i18n message keys per entity
+    i18n/index.ts                     (-i18n) merged "en" messages
+    orval.config.ts                   Orval: -orval-client endpoints and zod schemas; MSW handlers too with -mock
+    index.ts                          Barrel: generatedRoutes, api client, composables, types, utils
+    .generated-manifest.json          Files of the last run with content hashes (-prune removes stale ones)
 ================================================================================
 */
 
@@ -60,6 +102,15 @@ type TableMetadata struct {
 	Columns        []ColumnInfo    `json:"Columns"`
 	Relations      []*RelationNode `json:"Relations"`
 	Operations     []OperationInfo `json:"Operations"`
+
+	FilterParams []FilterParam `json:"FilterParams"` // Query parameters of the collection GET
+}
+
+type FilterParam struct {
+	Name     string   `json:"Name"`
+	Type     string   `json:"Type"`
+	Enum     []string `json:"Enum"`
+	Required bool     `json:"Required"`
 }
 
 type ColumnInfo struct {
@@ -68,11 +119,23 @@ type ColumnInfo struct {
 	Type        string            `json:"Type"`
 	Validation  string            `json:"Validation"`
 	Description string            `json:"Description"`
+	Example     string            `json:"Example"`
 	Additional  string            `json:"Additional"`
 	Constraints *FieldConstraints `json:"Constraints"`
 	Ref         string            `json:"Ref"`
 	IsArray     bool              `json:"IsArray"`
 	Source      string            `json:"Source"`
+
+	NestedColumns []ColumnInfo `json:"NestedColumns"` // Properties of an embedded object schema
+
+	Discriminator string        `json:"Discriminator"` // Property telling the Variants apart
+	Variants      []VariantInfo `json:"Variants"`      // Branches of a discriminated oneOf/anyOf
+}
+
+type VariantInfo struct {
+	Name    string       `json:"Name"`
+	Value   string       `json:"Value"`
+	Columns []ColumnInfo `json:"Columns"`
 }
 
 type FieldConstraints struct {
@@ -85,6 +148,11 @@ type FieldConstraints struct {
 	Pattern   string   `json:"Pattern"`
 	Format    string   `json:"Format"`
 	Enum      []string `json:"Enum"`
+	ReadOnly  bool     `json:"ReadOnly"`
+	WriteOnly bool     `json:"WriteOnly"`
+	Default   any      `json:"Default"`
+
+	EnumLabels []string `json:"EnumLabels"` // Display names of integer Enum values (Go const names)
 }
 
 type RelationNode struct {
@@ -95,6 +163,16 @@ type RelationNode struct {
 	SourceKey    string `json:"source_key"`
 	Validation   string `json:"validation"`
 	Description  string `json:"description"`
+	// Set by the parser for tree relations (target is the owning struct)
+	IsSelfReference bool `json:"is_self_reference"`
+	// M2M through a join table; rendered as a PivotSelect of target IDs
+	ThroughTable string         `json:"through_table"`
+	ThroughKeys  []RelationKeys `json:"through_keys"`
+}
+
+type RelationKeys struct {
+	TargetKey string `json:"target_key"`
+	SourceKey string `json:"source_key"`
 }
 
 type OperationInfo struct {
@@ -106,14 +184,77 @@ type OperationInfo struct {
 	RequestSchema  string   `json:"request_schema"`
 	ResponseSchema string   `json:"response_schema"`
 	Source         string   `json:"source"`
+	RequiresAuth   bool     `json:"requires_auth"`
 }
 
 // ======================== View Model Types ========================
 
+// GenOptions carries generator-wide settings from the command line into the
+// global and per-entity views so templates can branch on them.
+type GenOptions struct {
+	APIBase      string
+	OpenAPIURL   string
+	Mock         bool   // Orval also emits MSW request handlers with faker data
+	OrvalClient  string // Orval endpoints client (vue-query, react-query, axios, ...)
+	State        string // "vue-query" (composables) or "pinia" (stores)
+	I18n         bool   // Reference vue-i18n keys instead of literal English strings
+	Currency     string // Symbol prefixed to formatted money values
+	DateFormat   string // Quasar date.formatDate mask for dates; date-times append " HH:mm"
+	Timezone     string // IANA zone date-times are shown and edited in; "" for the browser's
+	DetailLayout string // "stacked" or "tabs" (DetailPage sub-table arrangement)
+	InlineRules  bool   // Inline rule arrays in components instead of {entity}Rules.ts
+	Auth         bool   // Generate LoginPage, useAuth and the /login route
+	AuthPath     string // Login endpoint, relative to the API base
+	RBAC         bool   // Guard create/edit/delete buttons with can('{entity_snake}.{action}')
+	Optimistic   bool   // Apply update/remove to the cached list before the server responds
+	Notify       bool   // Toast API errors and mutation successes via the $q passed to setupNotify
+	Retries      int    // Retries of idempotent requests on network errors and 502/503/504 (0 disables)
+	RetryBaseMs  int    // First retry delay; doubled on each further attempt
+
+	// List query parameter names and response total field (GoFrame defaults)
+	PageParam  string
+	SizeParam  string
+	SortParam  string
+	OrderParam string
+	TotalField string // Dotted path into the list payload (e.g. "meta.total")
+	Pagination string // "offset" (page numbers) or "cursor" (Hydra next/previous links)
+
+	// File uploads: endpoint and dotted path to the URL in its response
+	UploadURL   string
+	UploadField string
+
+	Thumbnails bool // Leading avatar column for entities with an image file field
+}
+
+// orvalClients are the values -orval-client accepts; zod is the second Orval output already.
+var orvalClients = []string{"vue-query", "react-query", "svelte-query", "swr", "axios", "axios-functions", "angular", "fetch"}
+
+// OrvalAxiosMutator reports whether the Orval client calls through an axios-style
+// mutator, so the endpoints can reuse customInstance from api/client.ts.
+func (o *GenOptions) OrvalAxiosMutator() bool {
+	return o.OrvalClient != "angular" && o.OrvalClient != "fetch"
+}
+
+// UsePinia reports whether per-entity state is generated as Pinia stores.
+func (o *GenOptions) UsePinia() bool { return o.State == "pinia" }
+
+// UseCursor reports whether lists follow Hydra next links instead of page numbers.
+func (o *GenOptions) UseCursor() bool { return o.Pagination == "cursor" }
+
+// TotalExpr renders the JS expression reading the list total from payload. The
+// default "total" keeps the GoFrame fallback to totalCount.
+func (o *GenOptions) TotalExpr(payload string) string {
+	if o.TotalField == "total" {
+		return payload + "?.total ?? " + payload + "?.totalCount"
+	}
+	return payload + jsPath(o.TotalField)
+}
+
 type GlobalView struct {
 	Entities   []EntityView
 	APIBaseURL string
 	OpenAPIURL string
+	Opts       *GenOptions
 }
 
 type EntityView struct {
@@ -126,14 +267,31 @@ type EntityView struct {
 	NamePluralLower string
 	NamePluralKebab string
 	NamePluralHuman string
+	Icon            string // Material icon for navigation (ad:"icon:name" hint on any column)
 	APIBasePath     string
-
-	PrimaryKey   string
-	DisplayField string
-
-	AllColumns  []ColumnView
-	ListColumns []ColumnView
-	FormFields  []ColumnView
+	APIItemPath     string // Detail/update/delete path with its {param} placeholder
+	PathParam       string // Name of that placeholder, also the detail route param (default "id")
+	PathField       string // Record field filling PathParam: the column of that name, else the PK
+
+	PrimaryKey        string
+	PKAutoIncrement   bool // Key assigned by the database; omitted from create forms
+	DisplayField      string
+	DisplayFieldLabel string // Lowercase human label of DisplayField (e.g. "display name")
+	SortColumn        string // Integer ordering column (sort/order/position/weight), if any
+	HasSortColumn     bool   // Rows can be reordered by drag and drop
+	CreatedAtField    string // created_at-style column shown in the DetailPage footer, if any
+	UpdatedAtField    string // updated_at-style column shown in the DetailPage footer, if any
+	SoftDeleteField   string // deleted_at-style column marking soft-deleted rows, if any
+	HasSoftDelete     bool   // "Show trashed" toggle (withTrashed=true) and a Restore action
+	ThumbnailField    string // Image file column shown as a leading avatar (-thumbnails)
+	DefaultSort       string // Initial list sort: SortColumn when present, else PrimaryKey
+
+	AllColumns    []ColumnView
+	ListColumns   []ColumnView
+	FormFields    []ColumnView
+	DetailColumns []ColumnView // AllColumns minus secrets (passwords)
+	FilterFields  []ColumnView // Enum and boolean form fields (or query parameters) offered as list filters
+	ParamFilters  []ColumnView // FilterFields from query parameters without a column, labelled {entity}.filter.*
 
 	TableRelations  []RelationView
 	SelectRelations []RelationView
@@ -142,30 +300,79 @@ type EntityView struct {
 	HasEnum          bool
 	HasRelations     bool
 	HasPivot         bool // M2M array-of-ID fields present
+	HasFKSelects     bool // Single-value relation (FK) selects present
 	HasNestedObjects bool // Embedded object/JSON fields present
+	HasNestedForms   bool // Nested objects edited with a structured sub-form
+	HasObjectArrays  bool // Arrays of objects edited as an inline table
+	HasColor         bool // Color picker fields present
+	HasListFile      bool // File columns shown as thumbnails in the list
+	HasFileArray     bool // Multi-file upload fields present
+	HasCurrency      bool // Money fields formatted with formatCurrency
+	HasDateTimeInput bool // Date-time form fields edited in local time, saved as UTC
+	HasNullable      bool // Form fields whose empty value is sent as null
+	HasOptionalNums  bool // Optional, non-nullable numeric form fields left out when cleared
+	HasBoolean       bool // Boolean columns present
+	HasFilters       bool // At least one enum/boolean filter on the IndexPage
+	HasPassword      bool // Password inputs with a visibility toggle
+	HasConfirmField  bool // A confirmation field must match its password field
+	UseDetailTabs    bool // -detail-layout tabs and more than one sub-table
 	Operations       []OperationInfo
+	OperationRefs    []OperationRef // Rendered into api/{entity}.operations.ts
 	CreateSchema     string
 	UpdateSchema     string
 	ZodImportPath    string
+
+	// utils/format functions used by IndexPage and DetailPage (e.g. "formatCurrency, formatDate")
+	ListFormatImports   string
+	DetailFormatImports string
+
+	Opts *GenOptions
+}
+
+// OperationRef is one OpenAPI operation as a typed constant for hand-written code.
+type OperationRef struct {
+	Key          string // Unique JS key: the operationId, else method plus path words
+	Method       string
+	Path         string // Spec path under the -api-base prefix, e.g. /api/users/{id}
+	OperationID  string
+	Summary      string
+	RequiresAuth bool // The spec's effective security requirement demands credentials
 }
 
 type ColumnView struct {
 	Name      string
 	JSONName  string
-	Label     string
+	Label     string // dc/description tag when present, else the humanized field name
+	LabelKey  string // vue-i18n key for Label ({entity_snake}.label.{json_name})
+	LabelTip  string // Full description when Label had to be shortened (header tooltip)
 	GoType    string
 	TSType    string
 	Component string
 	InputType string
+	InputStep string // step of number inputs: "1" integers, "any" floats, "0.01" money
+	InputMin  string // min of number inputs from the Minimum constraint
+	InputMax  string // max of number inputs from the Maximum constraint
 
 	IsPrimaryKey   bool
 	IsTextarea     bool
+	IsRichText     bool // ad:"richtext" hint: HTML edited with q-editor
 	IsFile         bool
+	IsFileArray    bool // Array of uploaded file URLs (multi-file uploader)
 	IsEnum         bool
 	IsRelation     bool
 	IsPivot        bool // M2M: array of scalar IDs
-	IsNestedObject bool // Embedded object or array of objects
+	IsNestedObject bool // Embedded object, or array of objects of unknown shape (JSON)
+	IsObjectArray  bool // Array of objects with a known shape (inline table of NestedFields)
+	IsColor        bool // Hex color string (rendered with a q-color popup)
+	IsDate         bool // OpenAPI format date or date-time (rendered with q-date-input)
+	IsDateTime     bool // OpenAPI format date-time (adds a q-time picker)
+	IsCurrency     bool // Money amount (formatted with the -currency symbol)
+	IsPassword     bool // Secret input; never listed or displayed
+	IsReadOnly     bool // OpenAPI readOnly: listed and displayed, never edited
+	IsWriteOnly    bool // OpenAPI writeOnly: edited, never listed or displayed
+	IsNullable     bool // Optional and nullable: empty means null, not '' or 0
 	IsArray        bool
+	ForceList      bool // ad:"list" hint: keep textarea/file columns in the list
 	Sortable       bool
 	Align          string
 
@@ -173,20 +380,33 @@ type ColumnView struct {
 	RelationEntityLower string
 	RelationEntityKebab string
 	RelationAPIPath     string
+	RelationLabelField  string // Target's display field used as the option label
 
 	EnumOptions string
+	Placeholder string // OpenAPI example (else description) shown as input placeholder/hint
+	Hint        string // Free text of the ad tag (directives removed), shown as input hint
+	Default     string // JS literal of the OpenAPI default for emptyForm, "" when absent
+	ConfirmOf   string // JSON name of the password field this one must repeat
+	EnumChips   string // JS map of enum value → { label, color } for q-chip display
 	QuasarRules string
 	Required    bool
+
+	NestedFields  []ColumnView // Sub-form inputs of a nested object whose shape is known
+	Discriminator string       // oneOf object: sub-field selecting the variant (first NestedField)
+	VariantKeys   string       // oneOf object: JS map of discriminator value → its sub-field names
+	VariantIf     string       // oneOf sub-field: v-if showing it for its variants only
 }
 
 type RelationView struct {
 	FieldName          string
+	FieldHuman         string // Human label of FieldName (1:1 card caption)
 	TargetEntity       string
 	TargetLower        string
 	TargetKebab        string
 	TargetPlural       string
 	TargetPluralKebab  string
 	TargetAPIPath      string // Full API path for fetching related items
+	TargetPathField    string // Target field filling its detail route param (1:1 card link)
 	TargetKey          string
 	SourceKey          string
 	IsCollection       bool
@@ -194,72 +414,207 @@ type RelationView struct {
 	TargetCreateSchema string
 	TargetUpdateSchema string
 	ZodImportPath      string
+	TargetRules        []ColumnView // Target columns carrying validation rules (for SubTableCrud)
+	TargetNumbers      []ColumnView // Target number columns with native step/min/max (for SubTableCrud)
+	TargetPrimaryKey   string       // Target's key, used to link 1:1 cards to its DetailPage
+	TargetDisplayField string       // Target field shown on 1:1 cards
+	IsSelfReference    bool         // Tree relation: "Children" sub-table (1:N) or "Parent" link (1:1)
 }
 
-// ======================== Template Constants — Global ========================
-
-//go:embed tplAPIClient.ts
-var tplAPIClient string
-
-//go:embed tplRouter.ts
-var tplRouter string
-
-//go:embed tplValidation.ts
-var tplValidation string
-
-//go:embed tplHydra.ts
-var tplHydra string
+// ======================== Templates ========================
 
-//go:embed tplZodBridge.ts
-var tplZodBridge string
-
-//go:embed tplOrvalConfig.ts
-var tplOrvalConfig string
-
-// ======================== Template Constants — Shared Components ========================
-
-// SubTableCrud provides embedded 1:N relation CRUD inside any detail page.
-// Dynamic columns are derived from response data, so no schema lookup is needed.
+// templateFS holds every generator template, one file per template. The template
+// name is the file name without its ".tmpl" extension (e.g. "index-page").
 //
-//go:embed tplSubTableCrud.vue
-var tplSubTableCrud string
-
-// PivotSelect provides a chip-based multi-select for M2M relationships.
-// Options are fetched from the target entity endpoint with type-ahead filtering.
+// Global templates: api-client, router, validation, hydra, zod-bridge, orval,
+// format, nav-menu, plus i18n-index with -i18n and login-page/use-auth/auth-guard with -auth.
 //
-//go:embed tplPivotSelect.vue
-var tplPivotSelect string
-
-// ======================== Template Constants — Per-Entity ========================
-
-//go:embed tplIndexPage.vue
-var tplIndexPage string
-
-//go:embed tplFormDialog.vue
-var tplFormDialog string
-
-//go:embed tplDetailPage.vue
-var tplDetailPage string
-
-//go:embed tplComposable.ts
-var tplComposable string
+// Shared templates: sub-table-crud (1:N inline CRUD; columns derived from
+// response data), pivot-select (M2M chip multi-select with type-ahead
+// filtering), use-confirm (shared delete confirmation), export (CSV/JSON
+// download) and display (isImageUrl, formatNested, truncate).
+//
+// Per-entity templates: index-page, form-dialog, detail-page, entity-types,
+// composable (vue-query) or store (pinia), entity-rules (unless -inline-rules),
+// plus i18n-messages with -i18n and operations when the schema lists operations.
+//
+//go:embed templates/*.tmpl
+var templateFS embed.FS
 
 // ======================== Main ========================
 
 func main() {
 	var (
-		schemaPath = flag.String("schema", "schema.logical.json", "Path to consolidated schema JSON")
-		outDir     = flag.String("out", "./src-gen", "Output directory for generated files")
-		apiBase    = flag.String("api-base", "/api", "API base URL prefix for composables")
-		openAPIURL = flag.String("openapi-url", "http://localhost:8000/api.json", "OpenAPI spec URL for Orval")
+		configPath   = flag.String("config", "", "YAML or JSON file of flag-name: value settings; command-line flags override it (optional)")
+		schemaPath   = flag.String("schema", "schema.logical.json", "Path to consolidated schema JSON")
+		outDir       = flag.String("out", "./src-gen", "Output directory for generated files")
+		apiBase      = flag.String("api-base", "/api", "API base URL prefix for composables")
+		openAPIURL   = flag.String("openapi-url", "http://localhost:8000/api.json", "OpenAPI spec URL for Orval")
+		orvalClient  = flag.String("orval-client", "vue-query", "Orval endpoints client: "+strings.Join(orvalClients, ", "))
+		mock         = flag.Bool("mock", false, "Have Orval also generate MSW request mocks (src/api/gen/endpoints/*.msw.ts) for local dev")
+		tplDir       = flag.String("templates", "", "Directory of {name}.tmpl files overriding built-in templates (optional)")
+		state        = flag.String("state", "vue-query", "Per-entity state layer: vue-query (composables) or pinia (stores)")
+		i18n         = flag.Bool("i18n", false, "Emit vue-i18n message files and t() lookups instead of literal labels")
+		currency     = flag.String("currency", "$", "Currency symbol used when formatting money fields")
+		dateFormat   = flag.String("date-format", "YYYY-MM-DD", "Quasar date mask for displayed dates; date-times add \" HH:mm\"")
+		timezone     = flag.String("timezone", "", "IANA time zone (e.g. Europe/Berlin) to show and edit date-times in instead of the browser's; the server stores UTC")
+		detailLayout = flag.String("detail-layout", "stacked", "DetailPage sub-tables: stacked or tabs (tabs only with 2+ sub-tables)")
+		inlineRules  = flag.Bool("inline-rules", false, "Inline validation rules in components instead of generating {entity}Rules.ts")
+		auth         = flag.Bool("auth", false, "Generate a login page, useAuth composable and /login route")
+		authPath     = flag.String("auth-path", "/auth/login", "Login endpoint POSTed by useAuth (relative to -api-base)")
+		rbac         = flag.Bool("rbac", false, "Show create/edit/delete buttons only when usePermissions().can('{entity}.{action}') allows")
+		optimistic   = flag.Bool("optimistic", false, "Optimistically apply update/remove to the list, rolling back on error")
+		retries      = flag.Int("retries", 3, "Retry idempotent requests this many times on network errors and 502/503/504 (0 disables)")
+		retryBaseMs  = flag.Int("retry-base-ms", 300, "Delay before the first retry in milliseconds, doubled on each attempt")
+		notify       = flag.Bool("notify", false, "Show API errors and create/update/delete successes as Quasar toasts (the app calls setupNotify($q) once)")
+		pageParam    = flag.String("page-param", "page", "Query parameter carrying the page number")
+		sizeParam    = flag.String("size-param", "pageSize", "Query parameter carrying the page size")
+		sortParam    = flag.String("sort-param", "orderBy", "Query parameter carrying the sort field")
+		orderParam   = flag.String("order-param", "orderDirection", "Query parameter carrying the sort direction (asc/desc)")
+		totalField   = flag.String("total-field", "total", "List response field holding the total row count (dotted path allowed)")
+		pagination   = flag.String("pagination", "offset", "List pagination: offset (page numbers) or cursor (Hydra next links)")
+		uploadURL    = flag.String("upload-url", "/api/upload", "Endpoint q-uploader posts files to")
+		uploadField  = flag.String("upload-field", "data.url", "Dotted path to the file URL in the upload response")
+		thumbnails   = flag.Bool("thumbnails", false, "Show an image file column as a leading avatar in IndexPage grids")
+		namesPath    = flag.String("names", "", "JSON file of per-struct {name, plural, human, kebab} naming overrides and extra \"acronyms\" (optional)")
+		pluralsPath  = flag.String("plurals", "", "JSON file of extra {\"singular\": \"plural\"} irregular plurals (optional)")
+		watch        = flag.Bool("watch", false, "Keep running and regenerate whenever the schema file changes")
+		dryRunFlag   = flag.Bool("dry-run", false, "Print a diff of what would change instead of writing; exit 1 if anything would")
+		jobs         = flag.Int("jobs", runtime.GOMAXPROCS(0), "Entities rendered concurrently")
+		validate     = flag.Bool("validate", false, "Check the output for syntax errors with tsc (and vue-tsc for .vue) when installed")
+		prune        = flag.Bool("prune", false, "Delete files listed in the previous "+manifestName+" that this run no longer generates")
 	)
 	flag.Parse()
 
-	schema, err := loadSchema(*schemaPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Failed to load schema: %v\n", err)
+	if *configPath != "" {
+		if err := loadConfig(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *state != "vue-query" && *state != "pinia" {
+		fmt.Fprintf(os.Stderr, "❌ Invalid -state %q (want vue-query or pinia)\n", *state)
+		os.Exit(1)
+	}
+	if *pagination != "offset" && *pagination != "cursor" {
+		fmt.Fprintf(os.Stderr, "❌ Invalid -pagination %q (want offset or cursor)\n", *pagination)
+		os.Exit(1)
+	}
+	if !slices.Contains(orvalClients, *orvalClient) {
+		fmt.Fprintf(os.Stderr, "❌ Invalid -orval-client %q (want %s)\n", *orvalClient, strings.Join(orvalClients, ", "))
+		os.Exit(1)
+	}
+	if *orvalClient != "vue-query" {
+		fmt.Printf("⚠️  -orval-client %s only changes Orval's endpoints; the generated Quasar pages and composables still use vue-query\n", *orvalClient)
+	}
+	if *jobs < 1 {
+		fmt.Fprintf(os.Stderr, "❌ Invalid -jobs %d (want >= 1)\n", *jobs)
+		os.Exit(1)
+	}
+	if *retries < 0 || *retryBaseMs < 0 {
+		fmt.Fprintf(os.Stderr, "❌ Invalid -retries %d / -retry-base-ms %d (want >= 0)\n", *retries, *retryBaseMs)
+		os.Exit(1)
+	}
+	if *detailLayout != "stacked" && *detailLayout != "tabs" {
+		fmt.Fprintf(os.Stderr, "❌ Invalid -detail-layout %q (want stacked or tabs)\n", *detailLayout)
+		os.Exit(1)
+	}
+	opts := &GenOptions{
+		APIBase:      *apiBase,
+		OpenAPIURL:   *openAPIURL,
+		Mock:         *mock,
+		OrvalClient:  *orvalClient,
+		State:        *state,
+		I18n:         *i18n,
+		Currency:     *currency,
+		DateFormat:   *dateFormat,
+		Timezone:     *timezone,
+		DetailLayout: *detailLayout,
+		InlineRules:  *inlineRules,
+		Auth:         *auth,
+		AuthPath:     *authPath,
+		RBAC:         *rbac,
+		Optimistic:   *optimistic,
+		Notify:       *notify,
+		Retries:      *retries,
+		RetryBaseMs:  *retryBaseMs,
+		PageParam:    *pageParam,
+		SizeParam:    *sizeParam,
+		SortParam:    *sortParam,
+		OrderParam:   *orderParam,
+		TotalField:   *totalField,
+		Pagination:   *pagination,
+		UploadURL:    *uploadURL,
+		UploadField:  *uploadField,
+		Thumbnails:   *thumbnails,
+	}
+
+	if *pluralsPath != "" {
+		if err := loadPlurals(*pluralsPath); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to load plurals: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *namesPath != "" {
+		if err := loadNameOverrides(*namesPath); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to load names: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	dryRun = *dryRunFlag
+	// Each run (and each -watch rerun) reports its own dry-run changes
+	run := func() error {
+		dryRunChanges.Store(0)
+		if err := generate(*schemaPath, *outDir, *tplDir, *jobs, *prune, opts); err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Printf("🔍 Dry run: %d file(s) would change\n", dryRunChanges.Load())
+			return nil
+		}
+		if *validate {
+			return validateOutput(*outDir)
+		}
+		return nil
+	}
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		if !*watch {
+			os.Exit(1)
+		}
+	}
+	if dryRun && !*watch && dryRunChanges.Load() > 0 {
 		os.Exit(1)
 	}
+	if *watch {
+		fmt.Printf("👀 Watching %s for changes (Ctrl+C to stop)\n", *schemaPath)
+		watchFile(*schemaPath, watchInterval, func() {
+			fmt.Printf("🔄 %s changed at %s, regenerating\n", *schemaPath, time.Now().Format("15:04:05"))
+			if err := run(); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			}
+		})
+	}
+}
+
+// generate renders every global, shared and per-entity file for the schema at
+// schemaPath into outDir, up to jobs entities at a time, and lists them in the
+// manifest (removing files only the previous run produced when prune is set).
+// Failures of single files are reported and skipped; an unreadable schema or
+// broken templates abort the run.
+func generate(schemaPath, outDir, tplDir string, jobs int, prune bool, opts *GenOptions) error {
+	manifest.Lock()
+	manifest.files = map[string]string{}
+	manifest.failed = map[string]bool{}
+	manifest.Unlock()
+
+	schema, err := loadSchema(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %w", err)
+	}
 
 	var entities []EntityView
 	seen := make(map[string]bool)
@@ -282,45 +637,52 @@ func main() {
 		if len(meta.Columns) == 0 && len(meta.Relations) == 0 {
 			continue
 		}
-		entities = append(entities, buildEntityView(meta, *apiBase, schema))
+		entities = append(entities, buildEntityView(meta, opts, schema))
 	}
 
 	sort.Slice(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
 
 	if len(entities) == 0 {
 		fmt.Println("⚠️  No entities found in schema. Nothing to generate.")
-		return
+		return nil
 	}
 
 	global := GlobalView{
 		Entities:   entities,
-		APIBaseURL: *apiBase,
-		OpenAPIURL: *openAPIURL,
+		APIBaseURL: opts.APIBase,
+		OpenAPIURL: opts.OpenAPIURL,
+		Opts:       opts,
 	}
 
 	funcMap := template.FuncMap{
-		"bt": func() string { return "`" },
+		"bt":    func() string { return "`" },
+		"jsKey": jsKey,
+	}
+	for name, fn := range i18nFuncs(opts.I18n) {
+		funcMap[name] = fn
 	}
 	templates := template.New("root").Delims("[[", "]]").Funcs(funcMap)
 
-	tplDefs := map[string]string{
-		"api-client":     tplAPIClient,
-		"router":         tplRouter,
-		"validation":     tplValidation,
-		"hydra":          tplHydra,
-		"zod-bridge":     tplZodBridge,
-		"orval":          tplOrvalConfig,
-		"sub-table-crud": tplSubTableCrud,
-		"pivot-select":   tplPivotSelect,
-		"index-page":     tplIndexPage,
-		"form-dialog":    tplFormDialog,
-		"detail-page":    tplDetailPage,
-		"composable":     tplComposable,
+	tplDefs, err := loadTemplateDefs(templateFS)
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
 	}
 	for name, content := range tplDefs {
+		if tplDir != "" {
+			overridePath := filepath.Join(tplDir, name+".tmpl")
+			override, err := loadTemplateOverride(overridePath, funcMap)
+			if err != nil {
+				// A broken override only disables its own template; the rest still render.
+				fmt.Fprintf(os.Stderr, "❌ Template override error (%s): %v\n", overridePath, err)
+				continue
+			}
+			if override != "" {
+				fmt.Printf("  🎨 Using template override %s\n", overridePath)
+				content = override
+			}
+		}
 		if _, err := templates.New(name).Parse(content); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Template parse error (%s): %v\n", name, err)
-			os.Exit(1)
+			return fmt.Errorf("template parse error (%s): %w", name, err)
 		}
 	}
 
@@ -329,12 +691,38 @@ func main() {
 		tpl, path string
 		data      any
 	}{
-		{"api-client", filepath.Join(*outDir, "api", "client.ts"), global},
-		{"router", filepath.Join(*outDir, "router", "generated-routes.ts"), global},
-		{"validation", filepath.Join(*outDir, "utils", "validation.ts"), nil},
-		{"hydra", filepath.Join(*outDir, "utils", "hydra.ts"), nil},
-		{"zod-bridge", filepath.Join(*outDir, "utils", "zod-to-quasar.ts"), nil},
-		{"orval", filepath.Join(*outDir, "orval.config.ts"), global},
+		{"api-client", filepath.Join(outDir, "api", "client.ts"), global},
+		{"router", filepath.Join(outDir, "router", "generated-routes.ts"), global},
+		{"validation", filepath.Join(outDir, "utils", "validation.ts"), nil},
+		{"hydra", filepath.Join(outDir, "utils", "hydra.ts"), nil},
+		{"zod-bridge", filepath.Join(outDir, "utils", "zod-to-quasar.ts"), nil},
+		{"orval", filepath.Join(outDir, "orval.config.ts"), global},
+		{"format", filepath.Join(outDir, "utils", "format.ts"), global},
+		{"nav-menu", filepath.Join(outDir, "components", "AppNavMenu.vue"), global},
+		{"composables-index", filepath.Join(outDir, "composables", "index.ts"), global},
+		{"src-index", filepath.Join(outDir, "index.ts"), global},
+	}
+	if opts.I18n {
+		globalFiles = append(globalFiles, struct {
+			tpl, path string
+			data      any
+		}{"i18n-index", filepath.Join(outDir, "i18n", "index.ts"), global})
+	}
+	if opts.Auth {
+		globalFiles = append(globalFiles, []struct {
+			tpl, path string
+			data      any
+		}{
+			{"login-page", filepath.Join(outDir, "pages", "LoginPage.vue"), global},
+			{"use-auth", filepath.Join(outDir, "composables", "useAuth.ts"), global},
+			{"auth-guard", filepath.Join(outDir, "router", "guard.ts"), global},
+		}...)
+	}
+	if opts.RBAC {
+		globalFiles = append(globalFiles, struct {
+			tpl, path string
+			data      any
+		}{"use-permissions", filepath.Join(outDir, "composables", "usePermissions.ts"), global})
 	}
 	for _, gf := range globalFiles {
 		if err := renderToFile(templates, gf.tpl, gf.path, gf.data); err != nil {
@@ -342,33 +730,217 @@ func main() {
 		}
 	}
 
-	// Shared reusable components (no template variables)
+	// Shared reusable components (only read .Opts from the global view)
 	sharedFiles := []struct{ tpl, path string }{
-		{"sub-table-crud", filepath.Join(*outDir, "components", "SubTableCrud.vue")},
-		{"pivot-select", filepath.Join(*outDir, "components", "PivotSelect.vue")},
+		{"sub-table-crud", filepath.Join(outDir, "components", "SubTableCrud.vue")},
+		{"pivot-select", filepath.Join(outDir, "components", "PivotSelect.vue")},
+		{"use-confirm", filepath.Join(outDir, "composables", "useConfirm.ts")},
+		{"export", filepath.Join(outDir, "utils", "export.ts")},
+		{"display", filepath.Join(outDir, "utils", "display.ts")},
 	}
 	for _, sf := range sharedFiles {
-		if err := renderToFile(templates, sf.tpl, sf.path, nil); err != nil {
+		if err := renderToFile(templates, sf.tpl, sf.path, global); err != nil {
 			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 		}
 	}
 
-	// Per-entity files
+	// Per-entity files: entities are independent, so a worker pool renders them
+	// concurrently (templates are safe for concurrent Execute)
+	queue := make(chan EntityView)
+	errs := make(chan error)
+	var failures []error
+	collected := make(chan struct{})
+	go func() {
+		for err := range errs {
+			failures = append(failures, err)
+		}
+		close(collected)
+	}()
+	var wg sync.WaitGroup
+	for range min(jobs, len(entities)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ev := range queue {
+				for _, ef := range entityFiles(ev, outDir, opts) {
+					if err := renderToFile(templates, ef.tpl, ef.path, ev); err != nil {
+						errs <- err
+					}
+				}
+			}
+		}()
+	}
 	for _, ev := range entities {
-		entityFiles := []struct{ tpl, path string }{
-			{"index-page", filepath.Join(*outDir, "pages", ev.NameKebab, "IndexPage.vue")},
-			{"form-dialog", filepath.Join(*outDir, "pages", ev.NameKebab, "FormDialog.vue")},
-			{"detail-page", filepath.Join(*outDir, "pages", ev.NameKebab, "DetailPage.vue")},
-			{"composable", filepath.Join(*outDir, "composables", "use"+ev.Name+".ts")},
-		}
-		for _, ef := range entityFiles {
-			if err := renderToFile(templates, ef.tpl, ef.path, ev); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		queue <- ev
+	}
+	close(queue)
+	wg.Wait()
+	close(errs)
+	<-collected
+	for _, err := range failures {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+	}
+
+	if err := writeManifest(outDir, prune); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Generated Quasar CRUD UI for %d entities in %s\n", len(entities), outDir)
+	return nil
+}
+
+// entityFiles lists the templates rendered for one entity and their output paths.
+func entityFiles(ev EntityView, outDir string, opts *GenOptions) []struct{ tpl, path string } {
+	stateTpl, statePath := "composable", filepath.Join(outDir, "composables", "use"+ev.Name+".ts")
+	if opts.UsePinia() {
+		stateTpl, statePath = "store", filepath.Join(outDir, "stores", "use"+ev.Name+"Store.ts")
+	}
+	files := []struct{ tpl, path string }{
+		{"index-page", filepath.Join(outDir, "pages", ev.NameKebab, "IndexPage.vue")},
+		{"form-dialog", filepath.Join(outDir, "pages", ev.NameKebab, "FormDialog.vue")},
+		{"detail-page", filepath.Join(outDir, "pages", ev.NameKebab, "DetailPage.vue")},
+		{stateTpl, statePath},
+		{"entity-types", filepath.Join(outDir, "types", ev.Name+".ts")},
+	}
+	if !opts.InlineRules {
+		files = append(files, struct{ tpl, path string }{"entity-rules", filepath.Join(outDir, "composables", ev.NameLower+"Rules.ts")})
+	}
+	if opts.I18n {
+		files = append(files, struct{ tpl, path string }{"i18n-messages", filepath.Join(outDir, "i18n", ev.NameKebab+".en.ts")})
+	}
+	if len(ev.OperationRefs) > 0 {
+		files = append(files, struct{ tpl, path string }{"operations", filepath.Join(outDir, "api", ev.NameKebab+".operations.ts")})
+	}
+	return files
+}
+
+// tscDiagnostic matches a tsc/vue-tsc "--pretty false" line:
+// file(line,col): error TS1005: ';' expected.
+var tscDiagnostic = regexp.MustCompile(`^(.+)\((\d+),(\d+)\): error TS(\d+): (.*)$`)
+
+// validateOutput runs tsc --noEmit over the generated .ts files, and vue-tsc over
+// the .vue files, reporting syntax errors (TS1xxx) by file and line. Other
+// diagnostics are only counted, as imports of vue, quasar, axios... cannot resolve
+// outside the app. A tool that is not installed skips its files with a warning.
+func validateOutput(outDir string) error {
+	var tsFiles, vueFiles []string
+	err := filepath.WalkDir(outDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".ts":
+			tsFiles = append(tsFiles, path)
+		case ".vue":
+			vueFiles = append(vueFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	syntaxErrors := 0
+	check := func(tool string, files []string) error {
+		if len(files) == 0 {
+			return nil
+		}
+		bin := findNodeTool(tool)
+		if bin == "" {
+			fmt.Printf("⚠️  %s not found in PATH or node_modules/.bin; %d file(s) not validated\n", tool, len(files))
+			return nil
+		}
+		args := append([]string{
+			"--noEmit", "--pretty", "false", "--skipLibCheck",
+			"--target", "es2022", "--module", "esnext", "--moduleResolution", "bundler",
+		}, files...)
+		out, err := exec.Command(bin, args...).CombinedOutput()
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			return fmt.Errorf("run %s: %w", tool, err)
+		}
+		ignored := 0
+		for _, line := range strings.Split(string(out), "\n") {
+			m := tscDiagnostic.FindStringSubmatch(strings.TrimSpace(line))
+			if m == nil {
+				continue
+			}
+			if code, _ := strconv.Atoi(m[4]); code >= 2000 {
+				ignored++
+				continue
 			}
+			syntaxErrors++
+			fmt.Fprintf(os.Stderr, "❌ %s:%s:%s TS%s: %s\n", m[1], m[2], m[3], m[4], m[5])
 		}
+		fmt.Printf("🔎 %s checked %d file(s)", tool, len(files))
+		if ignored > 0 {
+			fmt.Printf("; %d type diagnostic(s) ignored, type-check inside the app for those", ignored)
+		}
+		fmt.Println()
+		return nil
+	}
+	if err := check("tsc", tsFiles); err != nil {
+		return err
+	}
+	if err := check("vue-tsc", vueFiles); err != nil {
+		return err
+	}
+	if syntaxErrors > 0 {
+		return fmt.Errorf("validation found %d syntax error(s) in %s", syntaxErrors, outDir)
+	}
+	return nil
+}
+
+// findNodeTool returns the path of a Node CLI from PATH or the project's
+// node_modules/.bin, or "" when it is not installed.
+func findNodeTool(name string) string {
+	if path, err := exec.LookPath(name); err == nil {
+		return path
 	}
+	local := filepath.Join("node_modules", ".bin", name)
+	if info, err := os.Stat(local); err == nil && !info.IsDir() {
+		return local
+	}
+	return ""
+}
 
-	fmt.Printf("✅ Generated Quasar CRUD UI for %d entities in %s\n", len(entities), *outDir)
+// watchInterval is how often -watch polls the schema file. A change is acted on
+// only once the file has stopped changing for one interval, so an editor's or
+// parse_schema's burst of writes triggers a single regeneration.
+const watchInterval = 500 * time.Millisecond
+
+// watchFile polls path's size and modification time and calls onChange after
+// each settled change. It never returns.
+func watchFile(path string, interval time.Duration, onChange func()) {
+	stamp := func() string {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "" // Missing mid-write (rename/replace); the next poll sees the new file
+		}
+		return fmt.Sprintf("%d|%d", info.Size(), info.ModTime().UnixNano())
+	}
+	last := stamp()
+	for {
+		time.Sleep(interval)
+		current := stamp()
+		if current == last || current == "" {
+			continue
+		}
+		// Debounce: wait until two consecutive polls agree
+		for {
+			time.Sleep(interval)
+			next := stamp()
+			if next == current {
+				break
+			}
+			current = next
+		}
+		if current == "" {
+			continue
+		}
+		last = current
+		onChange()
+	}
 }
 
 // ======================== Schema Loading ========================
@@ -385,11 +957,229 @@ func loadSchema(path string) (*ConsolidatedSchema, error) {
 	return &cs, nil
 }
 
+// loadPlurals merges a JSON object of singular → plural words into irregularPlurals,
+// overriding built-in entries.
+func loadPlurals(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	var extra map[string]string
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	for singular, plural := range extra {
+		irregularPlurals[strings.ToLower(singular)] = strings.ToLower(plural)
+	}
+	return nil
+}
+
+// loadConfig applies a -config file of flag name → value to every flag not given
+// on the command line. JSON files hold one object; anything else is read as flat
+// YAML (see parseFlatYAML). All unknown keys are reported together.
+func loadConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	settings := map[string]string{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var raw map[string]any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		for key, value := range raw {
+			switch v := value.(type) {
+			case string:
+				settings[key] = v
+			case bool, float64:
+				settings[key] = fmt.Sprint(v)
+			default:
+				return fmt.Errorf("%s: %q must be a string, number or boolean", path, key)
+			}
+		}
+	} else if settings, err = parseFlatYAML(data); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	onCommandLine := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { onCommandLine[f.Name] = true })
+
+	var unknown []string
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		if key == "config" || flag.Lookup(key) == nil {
+			unknown = append(unknown, key)
+			continue
+		}
+		if onCommandLine[key] {
+			continue
+		}
+		if err := flag.Set(key, settings[key]); err != nil {
+			return fmt.Errorf("%s: %s: %w", path, key, err)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%s: unknown key(s) %s (run with -h for the flag names)", path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// parseFlatYAML reads the YAML subset a config file needs: one "key: value" per
+// line, "#" comments, optional "---", and plain, 'single' or "double" quoted
+// scalars. Nesting, lists and multi-line values are rejected with the line number.
+func parseFlatYAML(data []byte) (map[string]string, error) {
+	settings := map[string]string{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if trimmed := strings.TrimSpace(line); trimmed == "" || trimmed == "---" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || key == "" || key != strings.TrimSpace(key) {
+			return nil, fmt.Errorf("line %d: want an unindented \"key: value\"", i+1)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`):
+			end := strings.LastIndex(value, `"`)
+			if end == 0 || !yamlTrailer(value[end+1:]) {
+				return nil, fmt.Errorf("line %d: unterminated string", i+1)
+			}
+			unquoted, err := strconv.Unquote(value[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			end := strings.LastIndex(value, "'")
+			if end == 0 || !yamlTrailer(value[end+1:]) {
+				return nil, fmt.Errorf("line %d: unterminated string", i+1)
+			}
+			value = strings.ReplaceAll(value[1:end], "''", "'")
+		case value == "" || strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") ||
+			strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			return nil, fmt.Errorf("line %d: %s needs a single-line scalar value", i+1, key)
+		default:
+			if hash := strings.Index(value, " #"); hash >= 0 {
+				value = strings.TrimSpace(value[:hash])
+			}
+		}
+		if _, dup := settings[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %s", i+1, key)
+		}
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+// yamlTrailer reports whether rest, following a closing quote, is blank or a comment.
+func yamlTrailer(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
+
+// NameOverride holds explicit spellings for an entity whose automatic conversions
+// mangle acronyms or brand terms. Empty fields fall back to the derived value.
+type NameOverride struct {
+	Name   string `json:"name"`   // PascalCase entity name (e.g. "APIKey")
+	Plural string `json:"plural"` // Plural entity name (e.g. "APIKeys")
+	Human  string `json:"human"`  // Display label (e.g. "API Key")
+	Kebab  string `json:"kebab"`  // Route and file segment (e.g. "api-key")
+}
+
+// nameOverrides is keyed by struct name (or normalized entity name). Set by -names.
+var nameOverrides = map[string]NameOverride{}
+
+// loadNameOverrides reads a JSON object of struct name → NameOverride. The reserved
+// "acronyms" key holds a list of extra words to keep all-caps (e.g. ["SKU", "OAuth"]).
+func loadNameOverrides(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	for key, raw := range entries {
+		if key == "acronyms" {
+			var words []string
+			if err := json.Unmarshal(raw, &words); err != nil {
+				return fmt.Errorf("parse %s: acronyms: %w", path, err)
+			}
+			for _, w := range words {
+				acronyms[strings.ToUpper(w)] = true
+			}
+			continue
+		}
+		var o NameOverride
+		if err := json.Unmarshal(raw, &o); err != nil {
+			return fmt.Errorf("parse %s: %s: %w", path, key, err)
+		}
+		nameOverrides[key] = o
+	}
+	return nil
+}
+
+// ======================== Template Loading ========================
+
+// loadTemplateDefs walks the embedded templates directory and returns the
+// template sources keyed by name (file name without the ".tmpl" extension).
+func loadTemplateDefs(fsys fs.FS) (map[string]string, error) {
+	defs := make(map[string]string)
+	err := fs.WalkDir(fsys, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".tmpl" {
+			return nil
+		}
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		defs[strings.TrimSuffix(d.Name(), ".tmpl")] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// loadTemplateOverride reads a user-supplied template and checks that it parses.
+// A missing file yields ("", nil) so the caller falls back to the built-in template.
+func loadTemplateOverride(path string, funcMap template.FuncMap) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	content := string(data)
+	if _, err := template.New(filepath.Base(path)).Delims("[[", "]]").Funcs(funcMap).Parse(content); err != nil {
+		return "", err
+	}
+	return content, nil
+}
+
 // ======================== View Model Builders ========================
 
-func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSchema) EntityView {
+func buildEntityView(meta *TableMetadata, opts *GenOptions, schema *ConsolidatedSchema) EntityView {
+	apiBase := opts.APIBase
+	override, ok := nameOverrides[meta.StructName]
+	if !ok {
+		override = nameOverrides[meta.NormalizedName]
+	}
 	name := toPascal(meta.NormalizedName)
+	if override.Name != "" {
+		name = override.Name
+	}
 	plural := toPlural(name)
+	if override.Plural != "" {
+		plural = override.Plural
+	}
 
 	ev := EntityView{
 		Name:            name,
@@ -401,9 +1191,24 @@ func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSc
 		NamePluralLower: toCamel(plural),
 		NamePluralKebab: toKebab(plural),
 		NamePluralHuman: toHuman(plural),
-		APIBasePath:     apiBase + "/" + toKebab(plural),
 		Operations:      meta.Operations,
+		Opts:            opts,
+	}
+	// An explicit plural already set the plural forms; otherwise pluralize the overrides
+	if override.Human != "" {
+		ev.NameHuman = override.Human
+		if override.Plural == "" {
+			ev.NamePluralHuman = toPlural(override.Human)
+		}
+	}
+	if override.Kebab != "" {
+		ev.NameKebab = override.Kebab
+		if override.Plural == "" {
+			ev.NamePluralKebab = toPlural(override.Kebab)
+		}
 	}
+	ev.APIBasePath, ev.APIItemPath = entityAPIPaths(meta, apiBase, apiBase+"/"+ev.NamePluralKebab)
+	ev.OperationRefs = buildOperationRefs(meta.Operations, apiBase)
 
 	// Heuristic: Link Zod schemas from OpenAPI operations
 	for _, op := range meta.Operations {
@@ -411,7 +1216,7 @@ func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSc
 		if op.Method == "POST" && ev.CreateSchema == "" && len(op.Tags) > 0 {
 			if op.RequestSchema != "" {
 				tag := op.Tags[0]
-				pascalTag := toPascal(tag)
+				pascalTag := toPascalPlain(tag)
 				kebabTag := toKebab(tag)
 				ev.CreateSchema = "Post" + pascalTag + "Body"
 				if ev.ZodImportPath == "" {
@@ -423,7 +1228,7 @@ func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSc
 		if (op.Method == "PUT" || op.Method == "PATCH") && ev.UpdateSchema == "" && len(op.Tags) > 0 {
 			if op.RequestSchema != "" {
 				tag := op.Tags[0]
-				pascalTag := toPascal(tag)
+				pascalTag := toPascalPlain(tag)
 				kebabTag := toKebab(tag)
 				ev.UpdateSchema = strings.Title(op.Method) + pascalTag + "IdBody"
 				if ev.ZodImportPath == "" {
@@ -433,30 +1238,83 @@ func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSc
 		}
 	}
 
-	allCols := make([]ColumnView, 0, len(meta.Columns))
-	for _, col := range meta.Columns {
-		allCols = append(allCols, buildColumnView(col, apiBase))
+	ev.PrimaryKey, ev.PKAutoIncrement = detectPrimaryKey(meta)
+	ev.PathParam = pathParam(ev.APIItemPath)
+	ev.PathField = pathField(meta, ev.PathParam, ev.PrimaryKey)
+
+	columns := append(slices.Clone(meta.Columns), throughPivotColumns(meta)...)
+	allCols := make([]ColumnView, 0, len(columns))
+	for _, col := range columns {
+		cv := buildColumnView(col, ev.PrimaryKey, apiBase, schema)
+		cv.LabelKey = ev.NameSnake + ".label." + cv.JSONName
+		for i := range cv.NestedFields {
+			cv.NestedFields[i].LabelKey = cv.LabelKey + "_" + cv.NestedFields[i].JSONName
+		}
+		allCols = append(allCols, cv)
 	}
+	linkPasswordConfirms(allCols)
 	ev.AllColumns = allCols
 
-	ev.PrimaryKey = detectPrimaryKey(allCols)
-	ev.DisplayField = detectDisplayField(allCols, ev.PrimaryKey)
+	ev.Icon = defaultEntityIcon
+	for _, col := range meta.Columns {
+		if icon := hintValue(col.Additional, "icon"); icon != "" {
+			ev.Icon = icon
+			break
+		}
+	}
 
-	autoTimestamps := map[string]bool{
-		"created_at": true, "updated_at": true, "deleted_at": true,
-		"createdAt": true, "updatedAt": true, "deletedAt": true,
-		"create_at": true, "update_at": true, "delete_at": true,
+	ev.DisplayField = detectDisplayField(allCols, ev.PrimaryKey)
+	ev.DisplayFieldLabel = strings.ToLower(toHuman(ev.DisplayField))
+	if opts.Thumbnails {
+		ev.ThumbnailField = detectThumbnailField(allCols)
 	}
+	ev.SortColumn = detectSortColumn(allCols)
+	ev.HasSortColumn = ev.SortColumn != ""
+	ev.CreatedAtField = timestampField(allCols, "created")
+	ev.UpdatedAtField = timestampField(allCols, "updated")
+	ev.SoftDeleteField = timestampField(allCols, "deleted")
+	ev.HasSoftDelete = ev.SoftDeleteField != ""
+	ev.DefaultSort = ev.PrimaryKey
+	if ev.HasSortColumn {
+		ev.DefaultSort = ev.SortColumn
+	}
+
 	for _, cv := range allCols {
-		if !cv.IsTextarea && !cv.IsFile {
-			ev.ListColumns = append(ev.ListColumns, cv)
+		if cv.IsPassword {
+			ev.HasPassword = true
+			if cv.ConfirmOf != "" {
+				ev.HasConfirmField = true
+			}
+			// Form only: passwords never reach the list, detail page or export
+			ev.FormFields = append(ev.FormFields, cv)
+			continue
+		}
+		if !cv.IsWriteOnly {
+			ev.DetailColumns = append(ev.DetailColumns, cv)
+			if (!cv.IsTextarea && !cv.IsFile) || cv.ForceList {
+				ev.ListColumns = append(ev.ListColumns, cv)
+				if cv.IsFile && !cv.IsFileArray {
+					ev.HasListFile = true
+				}
+			}
+			if cv.IsEnum || cv.TSType == "boolean" {
+				ev.FilterFields = append(ev.FilterFields, cv)
+			}
 		}
-		if !cv.IsPrimaryKey && !autoTimestamps[cv.JSONName] {
+		// Natural (non auto-increment) keys are entered on create. OpenAPI readOnly marks
+		// server-managed fields explicitly; the timestamp names cover specs that don't.
+		if (!cv.IsPrimaryKey || !ev.PKAutoIncrement) && autoTimestamps[cv.JSONName] == "" && !cv.IsReadOnly {
 			ev.FormFields = append(ev.FormFields, cv)
 		}
+		if cv.TSType == "boolean" {
+			ev.HasBoolean = true
+		}
 		if cv.IsFile {
 			ev.HasFileUpload = true
 		}
+		if cv.IsFileArray {
+			ev.HasFileArray = true
+		}
 		if cv.IsEnum {
 			ev.HasEnum = true
 		}
@@ -466,12 +1324,38 @@ func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSc
 		if cv.IsPivot {
 			ev.HasPivot = true
 		}
-		if cv.IsNestedObject {
+		if cv.IsRelation {
+			ev.HasFKSelects = true
+		}
+		if cv.IsNestedObject || cv.IsObjectArray {
 			ev.HasNestedObjects = true
 		}
+		if len(cv.NestedFields) > 0 && !cv.IsReadOnly {
+			if cv.IsObjectArray {
+				ev.HasObjectArrays = true
+			} else {
+				ev.HasNestedForms = true
+			}
+		}
+		if cv.IsColor {
+			ev.HasColor = true
+		}
+		if cv.IsCurrency {
+			ev.HasCurrency = true
+		}
 	}
+	ev.HasDateTimeInput = slices.ContainsFunc(ev.FormFields, func(cv ColumnView) bool { return cv.IsDateTime })
+	ev.HasNullable = slices.ContainsFunc(ev.FormFields, func(cv ColumnView) bool { return cv.IsNullable })
+	ev.HasOptionalNums = slices.ContainsFunc(ev.FormFields, func(cv ColumnView) bool {
+		return cv.TSType == "number" && !cv.Required && !cv.IsNullable
+	})
+	ev.ListFormatImports = formatImports(ev.ListColumns, false)
+	ev.DetailFormatImports = formatImports(ev.DetailColumns, ev.CreatedAtField != "" || ev.UpdatedAtField != "")
 
 	for _, rel := range meta.Relations {
+		if rel.ThroughTable != "" {
+			continue // M2M: edited as a pivot column, see throughPivotColumns
+		}
 		rv := buildRelationView(rel, apiBase, schema)
 		if rel.IsCollection {
 			ev.TableRelations = append(ev.TableRelations, rv)
@@ -482,18 +1366,263 @@ func buildEntityView(meta *TableMetadata, apiBase string, schema *ConsolidatedSc
 	if len(ev.TableRelations) > 0 || len(ev.SelectRelations) > 0 {
 		ev.HasRelations = true
 	}
+	if len(meta.FilterParams) > 0 {
+		ev.FilterFields, ev.ParamFilters = paramFilterFields(meta.FilterParams, &ev, opts, apiBase, schema)
+	}
+	ev.HasFilters = len(ev.FilterFields) > 0
+	ev.UseDetailTabs = opts.DetailLayout == "tabs" && len(ev.TableRelations) > 1
 
 	return ev
 }
 
-// buildColumnView resolves a single schema column into template-ready metadata,
-// mapping Go types to Quasar components, detecting files/enums/relations/pivots/nested,
-// and pre-computing validation rules.
-func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
-	jsonName := col.JSONName
-	if jsonName == "" {
-		jsonName = col.Name // Preserve GoFrame's actual field name
+// entityAPIPaths returns the collection and item paths of an entity from its
+// OpenAPI operations, falling back to guess and guess + "/{id}". The collection is
+// the shortest GET without path parameters whose response is the entity (or
+// unspecified); the item path is the collection followed by one {param} segment.
+func entityAPIPaths(meta *TableMetadata, apiBase, guess string) (base, item string) {
+	collection := ""
+	for _, op := range meta.Operations {
+		if op.Method != "GET" || op.Path == "" || strings.Contains(op.Path, "{") {
+			continue
+		}
+		if op.ResponseSchema != "" && normalizeEntityName(op.ResponseSchema) != meta.NormalizedName {
+			continue
+		}
+		if collection == "" || len(op.Path) < len(collection) {
+			collection = op.Path
+		}
 	}
+	if collection == "" {
+		return guess, guess + "/{id}"
+	}
+	collection = strings.TrimSuffix(collection, "/")
+	item = collection + "/{id}"
+	for _, op := range meta.Operations {
+		rest, ok := strings.CutPrefix(op.Path, collection+"/")
+		if ok && strings.HasPrefix(rest, "{") && strings.HasSuffix(rest, "}") && !strings.Contains(rest, "/") {
+			item = op.Path
+			break
+		}
+	}
+	return withAPIBase(apiBase, collection), withAPIBase(apiBase, item)
+}
+
+// paramFilterFields offers the enum and boolean query parameters of the collection
+// GET as list filters, instead of guessing from the columns. A parameter named like
+// a column keeps its label (and its enum, when the parameter lists none). Paging,
+// sorting, search and withTrashed have their own controls.
+func paramFilterFields(params []FilterParam, ev *EntityView, opts *GenOptions, apiBase string, schema *ConsolidatedSchema) (fields, extra []ColumnView) {
+	reserved := map[string]bool{
+		opts.PageParam: true, opts.SizeParam: true, opts.SortParam: true, opts.OrderParam: true,
+		"search": true, "withTrashed": true,
+	}
+	for _, p := range params {
+		if reserved[p.Name] || !isJSIdent(p.Name) {
+			continue
+		}
+		i := slices.IndexFunc(ev.AllColumns, func(cv ColumnView) bool { return cv.JSONName == p.Name })
+		var cv ColumnView
+		if i >= 0 && len(p.Enum) == 0 {
+			cv = ev.AllColumns[i]
+		} else {
+			col := ColumnInfo{Name: p.Name, JSONName: p.Name, Type: p.Type}
+			if len(p.Enum) > 0 {
+				col.Constraints = &FieldConstraints{Enum: p.Enum}
+			}
+			cv = buildColumnView(col, "", apiBase, schema)
+			if i >= 0 {
+				cv.Label, cv.LabelKey = ev.AllColumns[i].Label, ev.AllColumns[i].LabelKey
+			}
+		}
+		if !cv.IsEnum && cv.TSType != "boolean" {
+			continue // The filter panel only has selects and toggles
+		}
+		if i < 0 {
+			cv.LabelKey = ev.NameSnake + ".filter." + cv.JSONName
+			extra = append(extra, cv)
+		}
+		fields = append(fields, cv)
+	}
+	return fields, extra
+}
+
+// pathParam returns the name of the {param} placeholder in an item path, or "id".
+func pathParam(itemPath string) string {
+	if i := strings.LastIndex(itemPath, "{"); i >= 0 {
+		if name := strings.TrimSuffix(itemPath[i+1:], "}"); name != "" && !strings.ContainsAny(name, "/}") {
+			return name
+		}
+	}
+	return "id"
+}
+
+// pathField returns the column whose value fills the item path placeholder: the
+// column named like the parameter (e.g. slug), else the primary key. The generic
+// "id" always means the primary key.
+func pathField(meta *TableMetadata, param, pk string) string {
+	if param == "id" {
+		return pk
+	}
+	for _, col := range meta.Columns {
+		if columnJSONName(col) == param {
+			return param
+		}
+	}
+	return pk
+}
+
+// withAPIBase prefixes a spec path with the API base unless it already carries it.
+func withAPIBase(apiBase, path string) string {
+	if apiBase == "" || path == apiBase || strings.HasPrefix(path, apiBase+"/") {
+		return path
+	}
+	return apiBase + path
+}
+
+// buildOperationRefs orders operations by path and method and gives each a unique
+// key. Operations without an operationId are keyed like getUsersByID.
+func buildOperationRefs(ops []OperationInfo, apiBase string) []OperationRef {
+	sorted := slices.Clone(ops)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	refs := make([]OperationRef, 0, len(sorted))
+	seen := make(map[string]int)
+	for _, op := range sorted {
+		key := op.OperationID
+		if key == "" {
+			words := []string{strings.ToLower(op.Method)}
+			for _, seg := range strings.Split(op.Path, "/") {
+				if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+					words = append(words, "by", strings.Trim(seg, "{}"))
+				} else if seg != "" {
+					words = append(words, seg)
+				}
+			}
+			key = toCamel(strings.Join(words, "_"))
+		}
+		if n := seen[key]; n > 0 {
+			seen[key]++
+			key = fmt.Sprintf("%s%d", key, n+1)
+		} else {
+			seen[key] = 1
+		}
+		refs = append(refs, OperationRef{
+			Key:          key,
+			Method:       op.Method,
+			Path:         withAPIBase(apiBase, op.Path),
+			OperationID:  op.OperationID,
+			Summary:      strings.Join(strings.Fields(op.Summary), " "),
+			RequiresAuth: op.RequiresAuth,
+		})
+	}
+	return refs
+}
+
+// setNestedObject marks an embedded object or array of objects. Arrays of a known
+// shape are edited as an inline table, single objects as a sub-form, and anything
+// else as JSON.
+func setNestedObject(cv *ColumnView, col ColumnInfo, apiBase string, schema *ConsolidatedSchema) {
+	cv.TSType = "any"
+	cv.Sortable = false
+	cv.NestedFields = buildNestedFields(col, apiBase, schema)
+	if len(col.Variants) > 0 && !col.IsArray {
+		cv.Discriminator = col.Discriminator
+		cv.NestedFields, cv.VariantKeys = buildVariantFields(cv.JSONName, col, apiBase, schema)
+	}
+	if col.IsArray && len(cv.NestedFields) > 0 {
+		cv.IsObjectArray = true
+	} else {
+		cv.IsNestedObject = true
+		if col.IsArray {
+			cv.NestedFields = nil
+		}
+	}
+	cv.QuasarRules = buildQuasarRules(*cv, col)
+}
+
+// buildNestedFields returns the inputs of an embedded object (or of each row of an
+// array of objects). Only plain scalar properties get an input; files, relations
+// and deeper nesting keep their current value untouched. Nil means the shape is
+// unknown.
+func buildNestedFields(col ColumnInfo, apiBase string, schema *ConsolidatedSchema) []ColumnView {
+	var fields []ColumnView
+	for _, nc := range col.NestedColumns {
+		ncv := buildColumnView(nc, "", apiBase, schema)
+		if ncv.IsReadOnly || ncv.IsNestedObject || ncv.IsFile || ncv.IsRelation || ncv.IsPivot || ncv.IsPassword {
+			continue
+		}
+		fields = append(fields, ncv)
+	}
+	return fields
+}
+
+// buildVariantFields returns the sub-form of a discriminated oneOf/anyOf object: a
+// required select of the discriminator, then the union of the variants' inputs, each
+// shown only while one of its variants is selected. keys maps every discriminator
+// value to its input names, so the payload can drop the other variants' values.
+func buildVariantFields(parent string, col ColumnInfo, apiBase string, schema *ConsolidatedSchema) (fields []ColumnView, keys string) {
+	values := make([]string, len(col.Variants))
+	options := make([]string, len(col.Variants))
+	for i, v := range col.Variants {
+		values[i] = v.Value
+		options[i] = fmt.Sprintf("{ label: '%s', value: '%s' }", escapeJSString(toHuman(v.Name)), escapeJSString(v.Value))
+	}
+	disc := buildColumnView(ColumnInfo{
+		Name:        col.Discriminator,
+		JSONName:    col.Discriminator,
+		Type:        "string",
+		Constraints: &FieldConstraints{Required: true, Enum: values, Default: values[0]},
+	}, "", apiBase, schema)
+	disc.EnumOptions = "[" + strings.Join(options, ", ") + "]"
+	fields = append(fields, disc)
+
+	owners := make(map[string][]string) // input name → variant values showing it
+	keyParts := make([]string, len(col.Variants))
+	for i, v := range col.Variants {
+		var names []string
+		for _, f := range buildNestedFields(ColumnInfo{NestedColumns: v.Columns}, apiBase, schema) {
+			if f.JSONName == col.Discriminator {
+				continue
+			}
+			if _, seen := owners[f.JSONName]; !seen {
+				fields = append(fields, f)
+			}
+			owners[f.JSONName] = append(owners[f.JSONName], "'"+escapeJSString(v.Value)+"'")
+			names = append(names, "'"+escapeJSString(f.JSONName)+"'")
+		}
+		keyParts[i] = fmt.Sprintf("%s: [%s]", jsKey(v.Value), strings.Join(names, ", "))
+	}
+
+	ref := "form." + parent + "." + col.Discriminator
+	if !isJSIdent(col.Discriminator) {
+		ref = "form." + parent + "['" + escapeJSString(col.Discriminator) + "']"
+	}
+	for i := 1; i < len(fields); i++ {
+		vals := owners[fields[i].JSONName]
+		if len(vals) == len(col.Variants) {
+			continue // Common to every variant
+		}
+		if len(vals) == 1 {
+			fields[i].VariantIf = ref + " === " + vals[0]
+		} else {
+			fields[i].VariantIf = "[" + strings.Join(vals, ", ") + "].includes(" + ref + ")"
+		}
+	}
+	return fields, "{ " + strings.Join(keyParts, ", ") + " }"
+}
+
+// buildColumnView resolves a single schema column into template-ready metadata,
+// mapping Go types to Quasar components, detecting files/enums/relations/pivots/nested,
+// and pre-computing validation rules. pk is the entity's primary key (see detectPrimaryKey).
+// The schema is used to resolve the display field of relation targets; pass nil to skip
+// cross-entity lookups.
+func buildColumnView(col ColumnInfo, pk, apiBase string, schema *ConsolidatedSchema) ColumnView {
+	jsonName := columnJSONName(col)
 
 	cv := ColumnView{
 		Name:      col.Name,
@@ -506,10 +1635,30 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 		Component: "q-input",
 		InputType: "text",
 		TSType:    "string",
+		ForceList: hasHint(col.Additional, "list"),
+		Hint:      adHint(col.Additional),
 	}
+	if desc := strings.TrimSpace(col.Description); desc != "" {
+		cv.Label = shortLabel(desc)
+		if cv.Label != desc {
+			cv.LabelTip = desc
+		}
+	}
+	// The description is the label now; only a shortened one is repeated as hint
+	cv.Placeholder = col.Example
+	if cv.Placeholder == "" {
+		cv.Placeholder = cv.LabelTip
+	}
+
+	// Fold GoFrame v-tag rules into the OpenAPI constraints (OpenAPI wins on conflicts)
+	col.Constraints = mergeGvalidConstraints(col.Constraints, col.Validation)
 
 	if col.Constraints != nil {
 		cv.Required = col.Constraints.Required
+		cv.IsNullable = col.Constraints.Nullable && !cv.Required
+		cv.IsReadOnly = col.Constraints.ReadOnly
+		cv.IsWriteOnly = col.Constraints.WriteOnly
+		cv.Default = formatDefault(col.Constraints)
 		if col.Constraints.Format != "" {
 			cv.InputType = mapFormatToInputType(col.Constraints.Format)
 		}
@@ -517,10 +1666,7 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 
 	lowerJSON := strings.ToLower(jsonName)
 
-	// Primary key detection
-	if lowerJSON == "id" {
-		cv.IsPrimaryKey = true
-	}
+	cv.IsPrimaryKey = jsonName == pk
 
 	// File upload detection (by format or naming convention)
 	if col.Constraints != nil && col.Constraints.Format == "binary" {
@@ -539,6 +1685,10 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 	if cv.IsFile {
 		cv.Component = "q-uploader"
 		cv.TSType = "string"
+		if col.IsArray {
+			cv.IsFileArray = true
+			cv.TSType = "string[]"
+		}
 		cv.Sortable = false
 		cv.QuasarRules = buildQuasarRules(cv, col)
 		return cv
@@ -548,7 +1698,17 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 	if col.Constraints != nil && len(col.Constraints.Enum) > 0 {
 		cv.IsEnum = true
 		cv.Component = "q-select"
-		cv.EnumOptions = formatEnumOptions(col.Constraints.Enum)
+		// Small option sets read better as a segmented toggle
+		if len(col.Constraints.Enum) <= maxToggleEnumOptions {
+			cv.Component = "q-btn-toggle"
+		}
+		// Integer enums (OpenAPI integer, Go iota consts) keep numeric values
+		numeric := isNumericType(col.Type)
+		if numeric {
+			cv.TSType = "number"
+		}
+		cv.EnumOptions = formatEnumOptions(col.Constraints.Enum, col.Constraints.EnumLabels, numeric)
+		cv.EnumChips = formatEnumChips(col.Constraints.Enum, col.Constraints.EnumLabels)
 		cv.QuasarRules = buildQuasarRules(cv, col)
 		return cv
 	}
@@ -559,15 +1719,18 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 		if strings.Contains(typeLower, "int") || strings.Contains(typeLower, "uint") || strings.Contains(typeLower, "string") {
 			lName := strings.ToLower(col.Name)
 			if strings.HasSuffix(lName, "ids") || strings.HasSuffix(lName, "_ids") {
-				cv.IsPivot = true
-				cv.Component = "pivot-select"
-				cv.TSType = "any[]"
-				cv.Sortable = false
 				rawEntity := strings.TrimSuffix(strings.TrimSuffix(lName, "_ids"), "ids")
 				rawEntity = strings.TrimRight(rawEntity, "_")
 				if rawEntity != "" {
-					setRelationFields(&cv, normalizeEntityName(rawEntity), apiBase)
+					setRelationFields(&cv, normalizeEntityName(rawEntity), apiBase, schema)
 				}
+				// Keep the target's API path and label field, but render a PivotSelect
+				// (multi-select) rather than the single-value relation q-select
+				cv.IsRelation = false
+				cv.IsPivot = true
+				cv.Component = "pivot-select"
+				cv.TSType = "any[]"
+				cv.Sortable = false
 				cv.QuasarRules = buildQuasarRules(cv, col)
 				return cv
 			}
@@ -578,23 +1741,16 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 	if !cv.IsPrimaryKey && !cv.IsFile && !cv.IsEnum && !cv.IsPivot {
 		if col.Ref != "" {
 			// Only treat as relation if field name follows FK naming convention
-			hasFKSuffix := strings.HasSuffix(lowerJSON, "_id") ||
-				(lowerJSON != "id" && len(lowerJSON) > 2 && strings.HasSuffix(lowerJSON, "id"))
+			hasFKSuffix := strings.HasSuffix(lowerJSON, "_id") || camelIDPrefix(jsonName) != ""
 			if !hasFKSuffix {
 				// $ref without FK suffix → embedded/nested object
-				cv.IsNestedObject = true
-				cv.TSType = "any"
-				cv.Sortable = false
-				cv.QuasarRules = buildQuasarRules(cv, col)
+				setNestedObject(&cv, col, apiBase, schema)
 				return cv
 			}
 		}
 		typeLower := strings.ToLower(col.Type)
 		if typeLower == "object" || (col.IsArray && col.Ref != "") {
-			cv.IsNestedObject = true
-			cv.TSType = "any"
-			cv.Sortable = false
-			cv.QuasarRules = buildQuasarRules(cv, col)
+			setNestedObject(&cv, col, apiBase, schema)
 			return cv
 		}
 	}
@@ -602,14 +1758,11 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 	// Relation detection (by $ref with FK suffix, or naming convention)
 	if !cv.IsPrimaryKey && !cv.IsFile && !cv.IsEnum && !cv.IsPivot && !cv.IsNestedObject {
 		if col.Ref != "" {
-			setRelationFields(&cv, normalizeEntityName(col.Ref), apiBase)
+			setRelationFields(&cv, normalizeEntityName(col.Ref), apiBase, schema)
 		} else if strings.HasSuffix(lowerJSON, "_id") {
-			setRelationFields(&cv, normalizeEntityName(strings.TrimSuffix(lowerJSON, "_id")), apiBase)
-		} else if lowerJSON != "id" && len(lowerJSON) > 2 && strings.HasSuffix(lowerJSON, "id") {
-			rawEntity := strings.TrimRight(strings.TrimSuffix(lowerJSON, "id"), "_")
-			if rawEntity != "" {
-				setRelationFields(&cv, normalizeEntityName(rawEntity), apiBase)
-			}
+			setRelationFields(&cv, normalizeEntityName(strings.TrimSuffix(lowerJSON, "_id")), apiBase, schema)
+		} else if rawEntity := camelIDPrefix(jsonName); rawEntity != "" {
+			setRelationFields(&cv, normalizeEntityName(rawEntity), apiBase, schema)
 		}
 	}
 
@@ -620,16 +1773,20 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 		case strings.Contains(typeLower, "int"), typeLower == "uint":
 			cv.TSType = "number"
 			cv.InputType = "number"
+			cv.InputStep = "1"
 			cv.Align = "right"
 		case strings.Contains(typeLower, "float"), strings.Contains(typeLower, "double"),
 			strings.Contains(typeLower, "decimal"):
 			cv.TSType = "number"
 			cv.InputType = "number"
+			cv.InputStep = "any"
 			cv.Align = "right"
 		case typeLower == "bool", typeLower == "boolean":
 			cv.TSType = "boolean"
 			cv.Component = "q-toggle"
 			cv.Align = "center"
+			// Booleans keep false: a toggle has no empty state to map to null
+			cv.IsNullable = false
 		default:
 			cv.TSType = "string"
 			if cv.InputType == "text" && col.Constraints != nil {
@@ -638,6 +1795,53 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 		}
 	}
 
+	// Currency detection: format "currency" or a money-like trailing name token
+	if cv.Component == "q-input" && (cv.TSType == "number" || isCurrencyFormat(col)) {
+		if isCurrencyFormat(col) || isCurrencyName(jsonName) {
+			cv.IsCurrency = true
+			cv.TSType = "number"
+			cv.InputType = "number"
+			cv.InputStep = "0.01"
+			cv.Align = "right"
+		}
+	}
+
+	// Native bounds for the number spinner; the JS rules stay authoritative
+	if cv.InputType == "number" && col.Constraints != nil {
+		if col.Constraints.Minimum != nil {
+			cv.InputMin = strconv.FormatFloat(*col.Constraints.Minimum, 'f', -1, 64)
+		}
+		if col.Constraints.Maximum != nil {
+			cv.InputMax = strconv.FormatFloat(*col.Constraints.Maximum, 'f', -1, 64)
+		}
+	}
+
+	// Password detection by format or name; value is never shown back
+	if cv.Component == "q-input" && cv.TSType == "string" {
+		isPasswordFormat := col.Constraints != nil && strings.EqualFold(col.Constraints.Format, "password")
+		if isPasswordFormat || strings.Contains(strings.ToLower(col.Name), "password") {
+			cv.IsPassword = true
+			cv.InputType = "password"
+			cv.Sortable = false
+		}
+	}
+
+	// Color detection by field name (hex string edited via q-color)
+	if cv.Component == "q-input" && cv.TSType == "string" {
+		nameLower := strings.ToLower(col.Name)
+		if strings.Contains(nameLower, "color") || strings.Contains(nameLower, "colour") {
+			cv.IsColor = true
+			cv.Component = "q-color"
+		}
+	}
+
+	// Date picker detection: value is kept as an ISO string in the form
+	if cv.Component == "q-input" && cv.TSType == "string" && (cv.InputType == "date" || cv.InputType == "datetime-local") {
+		cv.IsDate = true
+		cv.IsDateTime = cv.InputType == "datetime-local"
+		cv.Component = "q-date-input"
+	}
+
 	// Textarea detection by field name keywords (only for string q-input fields)
 	if cv.Component == "q-input" && cv.TSType == "string" && !cv.IsNestedObject {
 		textareaKW := []string{"description", "content", "body", "summary", "note", "comment", "bio", "text", "remark"}
@@ -651,17 +1855,103 @@ func buildColumnView(col ColumnInfo, apiBase string) ColumnView {
 		}
 	}
 
+	// Rich text is opt-in: only the ad:"richtext" hint switches to q-editor
+	if cv.Component == "q-input" && cv.TSType == "string" && hasHint(col.Additional, "richtext") {
+		cv.IsRichText = true
+		cv.IsTextarea = true
+		cv.Component = "q-editor"
+		cv.Sortable = false
+	}
+
 	cv.QuasarRules = buildQuasarRules(cv, col)
 	return cv
 }
 
-func setRelationFields(cv *ColumnView, target, apiBase string) {
+// camelIDPrefix returns "user" for camelCase foreign keys such as "userId" or
+// "userID", and "" when "id" is not preceded by a word boundary (uuid, grid, paid).
+func camelIDPrefix(name string) string {
+	for _, suffix := range []string{"Id", "ID"} {
+		prefix := strings.TrimSuffix(name, suffix)
+		if prefix == name || prefix == "" {
+			continue
+		}
+		last := prefix[len(prefix)-1]
+		if last >= 'a' && last <= 'z' || last >= '0' && last <= '9' {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func setRelationFields(cv *ColumnView, target, apiBase string, schema *ConsolidatedSchema) {
 	cv.IsRelation = true
 	cv.Component = "q-select"
 	cv.RelationEntity = toPascal(target)
 	cv.RelationEntityLower = toCamel(target)
 	cv.RelationEntityKebab = toKebab(target)
 	cv.RelationAPIPath = apiBase + "/" + toKebab(toPlural(target))
+	if meta := findEntityMeta(target, schema); meta != nil {
+		cv.RelationAPIPath, _ = entityAPIPaths(meta, apiBase, cv.RelationAPIPath)
+	}
+	cv.RelationLabelField = relationLabelField(target, apiBase, schema)
+}
+
+// findEntityMeta looks up a normalized entity name in the schema, or returns nil.
+func findEntityMeta(target string, schema *ConsolidatedSchema) *TableMetadata {
+	if schema == nil {
+		return nil
+	}
+	for _, m := range schema.EntityList {
+		if strings.EqualFold(m.NormalizedName, target) {
+			return m
+		}
+	}
+	return schema.Entities[toPascal(target)]
+}
+
+// relationLabelField returns the display field of the target entity, falling back
+// to "name" when the target is not part of the schema.
+func relationLabelField(target, apiBase string, schema *ConsolidatedSchema) string {
+	meta := findEntityMeta(target, schema)
+	if meta == nil {
+		return "name"
+	}
+	// nil schema: the target's own relations don't matter for its display field
+	pk, _ := detectPrimaryKey(meta)
+	cols := make([]ColumnView, 0, len(meta.Columns))
+	for _, col := range meta.Columns {
+		cols = append(cols, buildColumnView(col, pk, apiBase, nil))
+	}
+	return detectDisplayField(cols, pk)
+}
+
+// throughPivotColumns turns M2M relations through a join table into "{target}_ids"
+// columns, so they render as a PivotSelect like any array-of-IDs field. Relations
+// whose IDs column the schema already lists are skipped.
+func throughPivotColumns(meta *TableMetadata) []ColumnInfo {
+	var cols []ColumnInfo
+	for _, rel := range meta.Relations {
+		if rel.ThroughTable == "" {
+			continue
+		}
+		target := rel.TargetStruct
+		if idx := strings.LastIndex(target, "."); idx != -1 {
+			target = target[idx+1:]
+		}
+		name := toSnake(normalizeEntityName(target)) + "_ids"
+		if slices.ContainsFunc(meta.Columns, func(c ColumnInfo) bool { return columnJSONName(c) == name }) {
+			continue
+		}
+		cols = append(cols, ColumnInfo{
+			Name:        name,
+			JSONName:    name,
+			Type:        "int",
+			Validation:  rel.Validation,
+			Description: rel.Description,
+			IsArray:     true,
+		})
+	}
+	return cols
 }
 
 func buildRelationView(rel *RelationNode, apiBase string, schema *ConsolidatedSchema) RelationView {
@@ -669,6 +1959,7 @@ func buildRelationView(rel *RelationNode, apiBase string, schema *ConsolidatedSc
 	plural := toPlural(toPascal(target))
 	rv := RelationView{
 		FieldName:         rel.FieldName,
+		FieldHuman:        toHuman(rel.FieldName),
 		TargetEntity:      toPascal(target),
 		TargetLower:       toCamel(target),
 		TargetKebab:       toKebab(target),
@@ -679,6 +1970,7 @@ func buildRelationView(rel *RelationNode, apiBase string, schema *ConsolidatedSc
 		SourceKey:         rel.SourceKey,
 		IsCollection:      rel.IsCollection,
 		Description:       rel.Description,
+		IsSelfReference:   rel.IsSelfReference,
 	}
 
 	// Heuristic: Link Zod schemas from target entity's operations
@@ -698,7 +1990,7 @@ func buildRelationView(rel *RelationNode, apiBase string, schema *ConsolidatedSc
 			if op.Method == "POST" && rv.TargetCreateSchema == "" && len(op.Tags) > 0 {
 				if op.RequestSchema != "" {
 					tag := op.Tags[0]
-					pascalTag := toPascal(tag)
+					pascalTag := toPascalPlain(tag)
 					kebabTag := toKebab(tag)
 					rv.TargetCreateSchema = "Post" + pascalTag + "Body"
 					if rv.ZodImportPath == "" {
@@ -709,7 +2001,7 @@ func buildRelationView(rel *RelationNode, apiBase string, schema *ConsolidatedSc
 			if (op.Method == "PUT" || op.Method == "PATCH") && rv.TargetUpdateSchema == "" && len(op.Tags) > 0 {
 				if op.RequestSchema != "" {
 					tag := op.Tags[0]
-					pascalTag := toPascal(tag)
+					pascalTag := toPascalPlain(tag)
 					kebabTag := toKebab(tag)
 					rv.TargetUpdateSchema = strings.Title(op.Method) + pascalTag + "IdBody"
 					if rv.ZodImportPath == "" {
@@ -720,26 +2012,92 @@ func buildRelationView(rel *RelationNode, apiBase string, schema *ConsolidatedSc
 		}
 	}
 
+	rv.TargetPrimaryKey = "id"
+	rv.TargetDisplayField = relationLabelField(target, apiBase, schema)
+	rv.TargetPathField = rv.TargetPrimaryKey
+	if targetMeta != nil {
+		var itemPath string
+		rv.TargetAPIPath, itemPath = entityAPIPaths(targetMeta, apiBase, rv.TargetAPIPath)
+		pk, _ := detectPrimaryKey(targetMeta)
+		rv.TargetPrimaryKey = pk
+		rv.TargetPathField = pathField(targetMeta, pathParam(itemPath), pk)
+		for _, col := range targetMeta.Columns {
+			cv := buildColumnView(col, pk, apiBase, schema)
+			if !cv.IsPrimaryKey && cv.QuasarRules != "[]" {
+				rv.TargetRules = append(rv.TargetRules, cv)
+			}
+			if !cv.IsPrimaryKey && cv.InputType == "number" {
+				rv.TargetNumbers = append(rv.TargetNumbers, cv)
+			}
+		}
+	}
+
 	return rv
 }
 
 // ======================== Detection Helpers ========================
 
-func detectPrimaryKey(cols []ColumnView) string {
-	for _, c := range cols {
-		if strings.ToLower(c.JSONName) == "id" {
-			return c.JSONName
+// detectPrimaryKey picks the entity's key column and reports whether it is assigned
+// by the database: an integer key, unless it carries the pk hint without "auto",
+// or any key hinted ad:"auto" or readOnly. An ad:"pk" hint (OpenAPI x-primary-key) wins; then "id",
+// "{entity}_id"/"{entity}Id", "uid"/"uuid", any integer column containing "id",
+// and finally the first column.
+//
+// parse_schema carries an identical copy (with columnJSONName, hasHint, splitWords
+// and toSnake) for its diagrams; change both copies together.
+func detectPrimaryKey(meta *TableMetadata) (string, bool) {
+	cols := meta.Columns
+	isInt := func(c ColumnInfo) bool {
+		return !c.IsArray && strings.Contains(strings.ToLower(c.Type), "int")
+	}
+	// An explicit key is a natural one unless marked auto or readOnly
+	isAuto := func(c ColumnInfo) bool {
+		if hasHint(c.Additional, "auto") || c.Constraints != nil && c.Constraints.ReadOnly {
+			return true
 		}
+		return isInt(c) && !hasHint(c.Additional, "pk")
 	}
-	for _, c := range cols {
-		if strings.Contains(strings.ToLower(c.JSONName), "id") && c.TSType == "number" {
-			return c.JSONName
+	find := func(match func(c ColumnInfo) bool) (string, bool) {
+		for _, c := range cols {
+			if match(c) {
+				return columnJSONName(c), isAuto(c)
+			}
+		}
+		return "", false
+	}
+
+	entity := strings.ToLower(toSnake(meta.NormalizedName))
+	candidates := []func(c ColumnInfo) bool{
+		func(c ColumnInfo) bool { return hasHint(c.Additional, "pk") },
+		func(c ColumnInfo) bool { return strings.EqualFold(columnJSONName(c), "id") },
+		func(c ColumnInfo) bool {
+			return entity != "" && strings.ToLower(toSnake(columnJSONName(c))) == entity+"_id"
+		},
+		func(c ColumnInfo) bool {
+			name := strings.ToLower(columnJSONName(c))
+			return name == "uid" || name == "uuid"
+		},
+		func(c ColumnInfo) bool {
+			return strings.Contains(strings.ToLower(columnJSONName(c)), "id") && isInt(c)
+		},
+	}
+	for _, match := range candidates {
+		if pk, auto := find(match); pk != "" {
+			return pk, auto
 		}
 	}
 	if len(cols) > 0 {
-		return cols[0].JSONName
+		return columnJSONName(cols[0]), isAuto(cols[0])
 	}
-	return "id"
+	return "id", true
+}
+
+// columnJSONName is the wire name of a column, falling back to the Go field name.
+func columnJSONName(col ColumnInfo) string {
+	if col.JSONName == "" {
+		return col.Name // Preserve GoFrame's actual field name
+	}
+	return col.JSONName
 }
 
 func detectDisplayField(cols []ColumnView, pk string) string {
@@ -759,16 +2117,89 @@ func detectDisplayField(cols []ColumnView, pk string) string {
 	return pk
 }
 
+// detectThumbnailField finds a single-file column that holds a picture of the row
+// (avatar, photo, image, ...), or "" when there is none.
+func detectThumbnailField(cols []ColumnView) string {
+	for _, kw := range []string{"avatar", "photo", "image", "thumbnail", "picture", "logo", "cover"} {
+		for _, cv := range cols {
+			if cv.IsFile && !cv.IsFileArray && strings.Contains(strings.ToLower(cv.JSONName), kw) {
+				return cv.JSONName
+			}
+		}
+	}
+	return ""
+}
+
+// detectSortColumn finds an integer column that stores manual row ordering.
+func detectSortColumn(cols []ColumnView) string {
+	for _, cv := range cols {
+		if cv.TSType != "number" || !strings.Contains(strings.ToLower(cv.GoType), "int") {
+			continue
+		}
+		switch strings.ToLower(cv.JSONName) {
+		case "sort", "order", "position", "weight":
+			return cv.JSONName
+		}
+	}
+	return ""
+}
+
+// formatImports lists the utils/format functions that displaying cols needs, in a
+// fixed order; withDateTime adds formatDateTime for the DetailPage timestamp footer.
+func formatImports(cols []ColumnView, withDateTime bool) string {
+	var currency, date bool
+	for _, cv := range cols {
+		currency = currency || cv.IsCurrency
+		date = date || (cv.IsDate && !cv.IsDateTime)
+		withDateTime = withDateTime || cv.IsDateTime
+	}
+	var names []string
+	if currency {
+		names = append(names, "formatCurrency")
+	}
+	if date {
+		names = append(names, "formatDate")
+	}
+	if withDateTime {
+		names = append(names, "formatDateTime")
+	}
+	return strings.Join(names, ", ")
+}
+
+// autoTimestamps maps the server-managed timestamp columns (GoFrame's created_at,
+// updated_at and deleted_at, and their spellings) to what they record. They are never
+// form fields; see timestampField for the other uses.
+var autoTimestamps = map[string]string{
+	"created_at": "created", "updated_at": "updated", "deleted_at": "deleted",
+	"createdAt": "created", "updatedAt": "updated", "deletedAt": "deleted",
+	"create_at": "created", "update_at": "updated", "delete_at": "deleted",
+}
+
+// timestampField returns the first column whose auto timestamp records kind ("created",
+// "updated" or "deleted"), or "" if the entity has none.
+func timestampField(cols []ColumnView, kind string) string {
+	for _, cv := range cols {
+		if autoTimestamps[cv.JSONName] == kind {
+			return cv.JSONName
+		}
+	}
+	return ""
+}
+
 func mapFormatToInputType(format string) string {
 	switch strings.ToLower(format) {
 	case "email":
 		return "email"
-	case "date", "date-time":
+	case "date":
 		return "date"
+	case "date-time":
+		return "datetime-local"
 	case "uri", "url":
 		return "url"
 	case "password":
 		return "password"
+	case "phone":
+		return "tel"
 	case "time":
 		return "time"
 	default:
@@ -776,6 +2207,95 @@ func mapFormatToInputType(format string) string {
 	}
 }
 
+func isCurrencyFormat(col ColumnInfo) bool {
+	return col.Constraints != nil && strings.EqualFold(col.Constraints.Format, "currency")
+}
+
+// linkPasswordConfirms pairs confirmation fields (password_confirm,
+// confirm_password, password_confirmation) with the password field they repeat.
+func linkPasswordConfirms(cols []ColumnView) {
+	bySnake := make(map[string]string, len(cols))
+	for _, cv := range cols {
+		bySnake[toSnake(cv.JSONName)] = cv.JSONName
+	}
+	for i := range cols {
+		if !cols[i].IsPassword {
+			continue
+		}
+		snake := toSnake(cols[i].JSONName)
+		base := strings.TrimPrefix(snake, "confirm_")
+		base = strings.TrimSuffix(base, "_confirmation")
+		base = strings.TrimSuffix(base, "_confirm")
+		if base == snake {
+			continue
+		}
+		if target, ok := bySnake[base]; ok {
+			cols[i].ConfirmOf = target
+		}
+	}
+}
+
+// isCurrencyName matches money-like names on their last word, so unit_price and
+// totalAmount qualify but price_count does not.
+func isCurrencyName(name string) bool {
+	words := strings.Split(toSnake(name), "_")
+	switch words[len(words)-1] {
+	case "price", "amount", "cost", "total", "balance":
+		return true
+	}
+	return false
+}
+
+// hasHint reports whether the column's "ad" tag carries the given directive keyword.
+// Directives are separated by commas, pipes or whitespace (e.g. ad:"list,richtext").
+func hasHint(additional, keyword string) bool {
+	for _, f := range strings.FieldsFunc(additional, func(r rune) bool {
+		return r == ',' || r == '|' || unicode.IsSpace(r)
+	}) {
+		if strings.EqualFold(f, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// hintValue returns the argument of a "key:value" directive in the column's "ad"
+// tag (e.g. ad:"icon:people" yields "people"), or "" when absent.
+func hintValue(additional, key string) string {
+	for _, f := range strings.FieldsFunc(additional, func(r rune) bool {
+		return r == ',' || r == '|' || unicode.IsSpace(r)
+	}) {
+		if k, v, ok := strings.Cut(f, ":"); ok && strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// adDirectives are the ad tag keywords (or key:value keys) the generator acts on;
+// anything else in the tag is free text for the input hint.
+var adDirectives = []string{"list", "pk", "auto", "richtext", "icon"}
+
+// adHint returns the comma/pipe separated segments of the "ad" tag that are not
+// directives, e.g. ad:"list, Full legal name" yields "Full legal name".
+func adHint(additional string) string {
+	var parts []string
+	for _, seg := range strings.FieldsFunc(additional, func(r rune) bool { return r == ',' || r == '|' }) {
+		seg = strings.TrimSpace(seg)
+		key, _, _ := strings.Cut(seg, ":")
+		isDirective := slices.ContainsFunc(adDirectives, func(d string) bool {
+			return strings.EqualFold(strings.TrimSpace(key), d)
+		})
+		if seg != "" && !isDirective {
+			parts = append(parts, seg)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// defaultEntityIcon is used in the navigation menu when no icon hint is given.
+const defaultEntityIcon = "folder"
+
 // ======================== Validation ========================
 
 func buildQuasarRules(cv ColumnView, col ColumnInfo) string {
@@ -819,6 +2339,16 @@ func buildQuasarRules(cv ColumnView, col ColumnInfo) string {
 				"(val: any) => !val || /^[^\\s@]+@[^\\s@]+\\.[^\\s@]+$/.test(val) || '%s must be a valid email'",
 				escapeJSString(cv.Label)))
 		}
+		if c.Format == "phone" {
+			rules = append(rules, fmt.Sprintf(
+				"(val: any) => !val || /^\\+?[0-9\\s()-]{6,20}$/.test(String(val)) || '%s must be a valid phone number'",
+				escapeJSString(cv.Label)))
+		}
+		if c.Format == "url" || c.Format == "uri" {
+			rules = append(rules, fmt.Sprintf(
+				"(val: any) => !val || /^https?:\\/\\/\\S+$/.test(String(val)) || '%s must be a valid URL'",
+				escapeJSString(cv.Label)))
+		}
 	}
 
 	if len(rules) == 0 {
@@ -827,40 +2357,540 @@ func buildQuasarRules(cv ColumnView, col ColumnInfo) string {
 	return "[\n    " + strings.Join(rules, ",\n    ") + ",\n  ]"
 }
 
-func formatEnumOptions(enums []string) string {
+// mergeGvalidConstraints overlays GoFrame gvalid rules (the v:"..." tag, e.g.
+// "required|length:6,30|email|in:a,b") onto the OpenAPI constraints. Values already set by
+// OpenAPI are kept, so a rule present in both sources is emitted once. Unsupported
+// rules are ignored. The input is never mutated.
+func mergeGvalidConstraints(c *FieldConstraints, validation string) *FieldConstraints {
+	// Strip the optional "field@" prefix and "#messages" suffix
+	if i := strings.Index(validation, "@"); i >= 0 && !strings.ContainsAny(validation[:i], "|:") {
+		validation = validation[i+1:]
+	}
+	validation, _, _ = strings.Cut(validation, "#")
+	if strings.TrimSpace(validation) == "" {
+		return c
+	}
+	merged := &FieldConstraints{}
+	if c != nil {
+		*merged = *c
+	}
+
+	rest := validation
+	for rest != "" {
+		var rule string
+		// regex patterns may contain '|', so regex: consumes the rest of the tag
+		if strings.HasPrefix(strings.TrimSpace(rest), "regex:") {
+			rule, rest = strings.TrimSpace(rest), ""
+		} else if i := strings.Index(rest, "|"); i >= 0 {
+			rule, rest = strings.TrimSpace(rest[:i]), rest[i+1:]
+		} else {
+			rule, rest = strings.TrimSpace(rest), ""
+		}
+
+		name, arg, _ := strings.Cut(rule, ":")
+		args := strings.Split(arg, ",")
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "required":
+			merged.Required = true
+		case "length":
+			if len(args) == 2 {
+				setIntIfNil(&merged.MinLength, args[0])
+				setIntIfNil(&merged.MaxLength, args[1])
+			}
+		case "min-length":
+			setIntIfNil(&merged.MinLength, args[0])
+		case "max-length":
+			setIntIfNil(&merged.MaxLength, args[0])
+		case "min":
+			setFloatIfNil(&merged.Minimum, args[0])
+		case "max":
+			setFloatIfNil(&merged.Maximum, args[0])
+		case "between":
+			if len(args) == 2 {
+				setFloatIfNil(&merged.Minimum, args[0])
+				setFloatIfNil(&merged.Maximum, args[1])
+			}
+		case "email", "phone", "url":
+			if merged.Format == "" {
+				merged.Format = strings.ToLower(strings.TrimSpace(name))
+			}
+		case "regex":
+			if merged.Pattern == "" {
+				merged.Pattern = strings.TrimPrefix(strings.TrimSpace(rule), "regex:")
+			}
+		case "in":
+			if len(merged.Enum) == 0 {
+				for _, a := range args {
+					// in:"a b",'c' — values may be quoted
+					a = strings.Trim(strings.TrimSpace(a), `"'`)
+					if a != "" {
+						merged.Enum = append(merged.Enum, a)
+					}
+				}
+			}
+		}
+	}
+	return merged
+}
+
+func setIntIfNil(dst **int, s string) {
+	if *dst != nil {
+		return
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		*dst = &n
+	}
+}
+
+func setFloatIfNil(dst **float64, s string) {
+	if *dst != nil {
+		return
+	}
+	if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+		*dst = &f
+	}
+}
+
+// maxLabelRunes bounds labels taken from descriptions, so grid headers and
+// validation messages stay readable; longer ones are cut at a word.
+const maxLabelRunes = 32
+
+// shortLabel turns a description into a label: its first line without a trailing
+// period, cut at a word boundary with "…" beyond maxLabelRunes.
+func shortLabel(desc string) string {
+	desc, _, _ = strings.Cut(desc, "\n")
+	desc = strings.TrimSuffix(strings.TrimSpace(desc), ".")
+	runes := []rune(desc)
+	if len(runes) <= maxLabelRunes {
+		return desc
+	}
+	cut := string(runes[:maxLabelRunes-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:") + "…"
+}
+
+// maxToggleEnumOptions is the largest enum rendered as a q-btn-toggle
+// instead of a q-select.
+const maxToggleEnumOptions = 4
+
+// enumChipPalette is cycled through, in declaration order, to color enum
+// chips so the same schema always yields the same colors.
+var enumChipPalette = []string{"primary", "positive", "warning", "negative", "info", "secondary", "accent", "grey-7"}
+
+func formatEnumChips(enums, labels []string) string {
+	if len(enums) == 0 {
+		return "{}"
+	}
+	parts := make([]string, len(enums))
+	for i, e := range enums {
+		color := enumChipPalette[i%len(enumChipPalette)]
+		parts[i] = fmt.Sprintf("'%s': { label: '%s', color: '%s' }", escapeJSString(e), escapeJSString(enumLabel(enums, labels, i)), color)
+	}
+	return "{ " + strings.Join(parts, ", ") + " }"
+}
+
+// formatEnumOptions renders q-select/q-btn-toggle options; numeric enums get number
+// values so the form sends what an integer API field expects.
+func formatEnumOptions(enums, labels []string, numeric bool) string {
 	if len(enums) == 0 {
 		return "[]"
 	}
 	parts := make([]string, len(enums))
 	for i, e := range enums {
-		parts[i] = fmt.Sprintf("{ label: '%s', value: '%s' }", escapeJSString(toHuman(e)), escapeJSString(e))
+		value := "'" + escapeJSString(e) + "'"
+		if _, err := strconv.ParseFloat(e, 64); numeric && err == nil {
+			value = e
+		}
+		parts[i] = fmt.Sprintf("{ label: '%s', value: %s }", escapeJSString(enumLabel(enums, labels, i)), value)
 	}
 	return "[" + strings.Join(parts, ", ") + "]"
 }
 
+// enumLabel is the display label of enums[i]: its entry in labels when the schema
+// names the values (integer Go enums), else the humanized value.
+func enumLabel(enums, labels []string, i int) string {
+	if len(labels) == len(enums) && labels[i] != "" {
+		return toHuman(labels[i])
+	}
+	return toHuman(enums[i])
+}
+
+// isNumericType reports whether a schema column type holds numbers.
+func isNumericType(goType string) bool {
+	t := strings.ToLower(goType)
+	return strings.Contains(t, "int") || strings.Contains(t, "float") || strings.Contains(t, "double") ||
+		strings.Contains(t, "decimal") || t == "number"
+}
+
 // ======================== Rendering ========================
 
+// dryRun makes renderToFile report what would change instead of writing
+// (-dry-run); dryRunChanges counts the new or modified files.
+var (
+	dryRun        bool
+	dryRunChanges atomic.Int64
+)
+
 func renderToFile(templates *template.Template, name, outPath string, data any) error {
+	tpl := templates.Lookup(name)
+	if tpl == nil {
+		recordFailure(outPath)
+		return fmt.Errorf("template %q not found", name)
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		recordFailure(outPath)
+		return fmt.Errorf("execute %s: %w", name, err)
+	}
+	recordOutput(outPath, buf.Bytes())
+
+	if dryRun {
+		old, err := os.ReadFile(outPath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			dryRunChanges.Add(1)
+			fmt.Printf("  🆕 %s (new file)\n", outPath)
+		case err != nil:
+			return fmt.Errorf("read %s: %w", outPath, err)
+		case bytes.Equal(old, buf.Bytes()):
+			fmt.Printf("  ✔  %s (no change)\n", outPath)
+		default:
+			dryRunChanges.Add(1)
+			fmt.Print(unifiedDiff(outPath, string(old), buf.String()))
+		}
+		return nil
+	}
+
+	// Identical output is left alone so mtimes (and build caches) survive a rerun
+	if old, err := os.ReadFile(outPath); err == nil && bytes.Equal(old, buf.Bytes()) {
+		fmt.Printf("  ✔  %s (unchanged)\n", outPath)
+		return nil
+	}
 	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
 		return fmt.Errorf("mkdir for %s: %w", outPath, err)
 	}
-	f, err := os.Create(outPath)
+	if err := os.WriteFile(outPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", outPath, err)
+	}
+	fmt.Printf("  📄 %s\n", outPath)
+	return nil
+}
+
+// manifestName is the file in the output directory listing what the last run
+// generated, so -prune can remove outputs of renamed or deleted entities.
+const manifestName = ".generated-manifest.json"
+
+// Manifest is the JSON form of manifestName: output-relative slash paths with
+// the SHA-256 of the content written.
+type Manifest struct {
+	GeneratedBy string          `json:"generated_by"`
+	Files       []ManifestEntry `json:"files"`
+}
+
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// manifest collects the files rendered by the current run (path -> content hash)
+// and those whose template failed; per-entity workers record concurrently.
+var manifest = struct {
+	sync.Mutex
+	files  map[string]string
+	failed map[string]bool
+}{files: map[string]string{}, failed: map[string]bool{}}
+
+func recordOutput(path string, content []byte) {
+	sum := sha256.Sum256(content)
+	manifest.Lock()
+	manifest.files[path] = hex.EncodeToString(sum[:])
+	manifest.Unlock()
+}
+
+// recordFailure notes an output whose template could not run, so writeManifest
+// keeps the previous manifest and skips pruning.
+func recordFailure(path string) {
+	manifest.Lock()
+	manifest.failed[path] = true
+	manifest.Unlock()
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("create %s: %w", outPath, err)
+		return "", err
 	}
-	defer f.Close()
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	tpl := templates.Lookup(name)
-	if tpl == nil {
-		return fmt.Errorf("template %q not found", name)
+// writeManifest writes this run's manifest to outDir (not in -dry-run). With
+// prune, files of the previous manifest that were not generated this time are
+// deleted, unless edited since (their hash no longer matches), then any
+// directories left empty. When a template failed, nothing is pruned and the
+// previous entries are carried over, since the run's file list is incomplete.
+func writeManifest(outDir string, prune bool) error {
+	manifestPath := filepath.Join(outDir, manifestName)
+	current := Manifest{GeneratedBy: "gen_quasar"}
+	generated := map[string]bool{}
+	failed := map[string]bool{}
+	relPath := func(path string) string {
+		rel, err := filepath.Rel(outDir, path)
+		if err != nil {
+			rel = path
+		}
+		return filepath.ToSlash(rel)
 	}
-	if err := tpl.Execute(f, data); err != nil {
-		return fmt.Errorf("execute %s: %w", name, err)
+	manifest.Lock()
+	for path, hash := range manifest.files {
+		rel := relPath(path)
+		generated[rel] = true
+		current.Files = append(current.Files, ManifestEntry{Path: rel, SHA256: hash})
+	}
+	for path := range manifest.failed {
+		failed[relPath(path)] = true
+	}
+	manifest.Unlock()
+
+	var previous Manifest
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &previous); err != nil && prune {
+			return fmt.Errorf("parse %s: %w", manifestPath, err)
+		}
+	}
+	// A run with failed templates has an incomplete file list: keep the previous
+	// entries it did not produce, so a later complete run can still prune them
+	for _, entry := range previous.Files {
+		if len(failed) > 0 && !generated[entry.Path] {
+			current.Files = append(current.Files, entry)
+			generated[entry.Path] = true
+		}
+	}
+	sort.Slice(current.Files, func(i, j int) bool { return current.Files[i].Path < current.Files[j].Path })
+
+	if prune && len(failed) > 0 {
+		fmt.Printf("  ⚠️  %d file(s) failed to render; skipping -prune\n", len(failed))
+	} else if prune {
+		for _, entry := range previous.Files {
+			if generated[entry.Path] || strings.HasPrefix(entry.Path, "../") {
+				continue
+			}
+			path := filepath.Join(outDir, filepath.FromSlash(entry.Path))
+			hash, err := hashFile(path)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				continue
+			case err != nil:
+				return fmt.Errorf("read %s: %w", path, err)
+			case hash != entry.SHA256:
+				fmt.Printf("  ⚠️  %s is no longer generated but was edited; keeping it\n", path)
+			case dryRun:
+				dryRunChanges.Add(1)
+				fmt.Printf("  🗑️  %s (would be removed)\n", path)
+			default:
+				if err := os.Remove(path); err != nil {
+					return fmt.Errorf("remove %s: %w", path, err)
+				}
+				fmt.Printf("  🗑️  %s (removed)\n", path)
+				// Drop directories the removal emptied, up to outDir
+				for dir := filepath.Dir(path); dir != filepath.Clean(outDir); dir = filepath.Dir(dir) {
+					if os.Remove(dir) != nil {
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+	data, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if old, err := os.ReadFile(manifestPath); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("mkdir for %s: %w", manifestPath, err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", manifestPath, err)
 	}
-	fmt.Printf("  📄 %s\n", outPath)
 	return nil
 }
 
+// diffContext is the number of unchanged lines shown around each -dry-run hunk.
+const diffContext = 3
+
+// diffOp is one line of a line diff: ' ' kept, '-' removed, '+' added. oldLine and
+// newLine are the 0-based positions before the op in the old and new text.
+type diffOp struct {
+	kind             byte
+	text             string
+	oldLine, newLine int
+}
+
+// unifiedDiff renders the line changes from oldText to newText in unified diff
+// format (--- a/path, +++ b/path, @@ hunks), or "" when they are equal.
+func unifiedDiff(path, oldText, newText string) string {
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", path, path)
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		// Grow the hunk while the next change is within 2*diffContext kept lines
+		start := max(i-diffContext, 0)
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		end = min(end+diffContext, len(ops))
+
+		var oldCount, newCount int
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		oldStart, newStart := ops[start].oldLine, ops[start].newLine
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			sb.WriteByte('\n')
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a minimal line diff via longest common subsequence. The
+// common prefix and suffix are trimmed first, so regenerated files with a few
+// edits stay cheap.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] = LCS length of ma[i:] and mb[j:]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	oi, ni := 0, 0
+	emit := func(kind byte, text string) {
+		ops = append(ops, diffOp{kind: kind, text: text, oldLine: oi, newLine: ni})
+		if kind != '+' {
+			oi++
+		}
+		if kind != '-' {
+			ni++
+		}
+	}
+	for _, line := range a[:prefix] {
+		emit(' ', line)
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			emit(' ', ma[i])
+			i++
+			j++
+		case i < len(ma) && (j == len(mb) || lcs[i+1][j] >= lcs[i][j+1]):
+			emit('-', ma[i])
+			i++
+		default:
+			emit('+', mb[j])
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		emit(' ', line)
+	}
+	return ops
+}
+
+// ======================== Template Helpers ========================
+
+// i18nFuncs returns helpers that render a user-facing string either as a literal
+// (default) or as a vue-i18n lookup of key when -i18n is enabled.
+//
+//	tAttr "label" key text  ->  label="Text"     | :label="t('key')"
+//	tText key text          ->  Text             | {{ t('key') }}
+//	tExpr key text          ->  'Text'           | t('key')
+func i18nFuncs(enabled bool) template.FuncMap {
+	return template.FuncMap{
+		"tAttr": func(attr, key, text string) string {
+			if enabled {
+				return fmt.Sprintf(`:%s="t('%s')"`, attr, key)
+			}
+			return fmt.Sprintf(`%s="%s"`, attr, strings.ReplaceAll(text, `"`, "&quot;"))
+		},
+		"tText": func(key, text string) string {
+			if enabled {
+				return "{{ t('" + key + "') }}"
+			}
+			// Labels may come from free-text descriptions
+			return template.HTMLEscapeString(text)
+		},
+		"tExpr": func(key, text string) string {
+			if enabled {
+				return "t('" + key + "')"
+			}
+			return "'" + escapeJSString(text) + "'"
+		},
+		"jsStr": escapeJSString,
+	}
+}
+
 // ======================== String Utilities ========================
 
 func splitWords(s string) []string {
@@ -894,7 +2924,37 @@ func splitWords(s string) []string {
 	return words
 }
 
+// acronyms are kept all-caps by toPascal and toHuman ("apiUrl" → "APIURL", "API URL").
+// Extended by the "acronyms" list of the -names file.
+var acronyms = map[string]bool{
+	"HTTP": true, "URL": true, "API": true, "ID": true,
+	"UUID": true, "SQL": true, "JSON": true, "HTML": true,
+}
+
+// titleWord capitalizes a word, or upper-cases it entirely when it is an acronym.
+func titleWord(w string) string {
+	if acronyms[strings.ToUpper(w)] {
+		return strings.ToUpper(w)
+	}
+	r := []rune(strings.ToLower(w))
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
 func toPascal(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		if w == "" {
+			continue
+		}
+		words[i] = titleWord(w)
+	}
+	return strings.Join(words, "")
+}
+
+// toPascalPlain is toPascal without acronym handling; it matches the names Orval
+// derives from OpenAPI tags (e.g. "PostApiKeyBody").
+func toPascalPlain(s string) string {
 	words := splitWords(s)
 	for i, w := range words {
 		if w == "" {
@@ -912,6 +2972,11 @@ func toCamel(s string) string {
 	if p == "" {
 		return p
 	}
+	// A leading acronym is lowered as a whole: "APIKey" → "apiKey"
+	if words := splitWords(s); len(words) > 0 && acronyms[strings.ToUpper(words[0])] {
+		n := len(words[0])
+		return strings.ToLower(p[:n]) + p[n:]
+	}
 	r := []rune(p)
 	r[0] = unicode.ToLower(r[0])
 	return string(r)
@@ -939,17 +3004,78 @@ func toHuman(s string) string {
 		if w == "" {
 			continue
 		}
-		r := []rune(strings.ToLower(w))
-		r[0] = unicode.ToUpper(r[0])
-		words[i] = string(r)
+		words[i] = titleWord(w)
 	}
 	return strings.Join(words, " ")
 }
 
+// irregularPlurals maps lowercase singulars to plurals that the suffix rules in
+// toPlural get wrong. Extended with -plurals.
+var irregularPlurals = map[string]string{
+	"person":    "people",
+	"child":     "children",
+	"man":       "men",
+	"woman":     "women",
+	"mouse":     "mice",
+	"goose":     "geese",
+	"foot":      "feet",
+	"tooth":     "teeth",
+	"ox":        "oxen",
+	"datum":     "data",
+	"medium":    "media",
+	"criterion": "criteria",
+	"index":     "indices",
+	"matrix":    "matrices",
+	"vertex":    "vertices",
+	"analysis":  "analyses",
+	"axis":      "axes",
+	"crisis":    "crises",
+	"leaf":      "leaves",
+	"knife":     "knives",
+	"life":      "lives",
+	"sheep":     "sheep",
+	"series":    "series",
+	"species":   "species",
+	"news":      "news",
+}
+
+// irregularPlural pluralizes s via irregularPlurals, matching either the whole word
+// or its last camelCase word ("SalesPerson" → "SalesPeople") and keeping the casing.
+// The longest matching singular wins.
+func irregularPlural(s string) (string, bool) {
+	lower := strings.ToLower(s)
+	best := ""
+	for singular := range irregularPlurals {
+		if len(singular) <= len(best) || !strings.HasSuffix(lower, singular) {
+			continue
+		}
+		i := len(s) - len(singular)
+		if i > 0 && !unicode.IsUpper(rune(s[i])) && s[i-1] != '_' && s[i-1] != '-' {
+			continue // "Human" must not match "man"
+		}
+		best = singular
+	}
+	if best == "" {
+		return "", false
+	}
+	i := len(s) - len(best)
+	word, plural := s[i:], irregularPlurals[best]
+	switch {
+	case len(word) > 1 && word == strings.ToUpper(word):
+		plural = strings.ToUpper(plural)
+	case unicode.IsUpper(rune(word[0])):
+		plural = strings.ToUpper(plural[:1]) + plural[1:]
+	}
+	return s[:i] + plural, true
+}
+
 func toPlural(s string) string {
 	if s == "" {
 		return s
 	}
+	if p, ok := irregularPlural(s); ok {
+		return p
+	}
 	lower := strings.ToLower(s)
 	for _, suf := range []string{"ies", "ses", "xes", "zes", "ches", "shes"} {
 		if strings.HasSuffix(lower, suf) {
@@ -993,8 +3119,61 @@ func normalizeEntityName(name string) string {
 	return cleaned
 }
 
+// formatDefault renders a scalar OpenAPI default as a JS literal. Enum defaults are
+// kept only when they match an option; objects and arrays are ignored.
+func formatDefault(c *FieldConstraints) string {
+	switch v := c.Default.(type) {
+	case string:
+		if len(c.Enum) > 0 && !slices.Contains(c.Enum, v) {
+			return ""
+		}
+		return "'" + escapeJSString(v) + "'"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
 func escapeJSString(s string) string {
 	s = strings.ReplaceAll(s, `\`, `\\`)
 	s = strings.ReplaceAll(s, `'`, `\'`)
 	return s
 }
+
+// isJSIdent reports whether s can be used unquoted as an object key or after ".".
+func isJSIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// jsKey renders s as an object literal key, quoting it only when needed.
+func jsKey(s string) string {
+	if isJSIdent(s) {
+		return s
+	}
+	return "'" + escapeJSString(s) + "'"
+}
+
+// jsPath renders a dotted field path as optional-chained property access
+// (e.g. "meta.total" -> "?.meta?.total").
+func jsPath(path string) string {
+	var b strings.Builder
+	for _, seg := range strings.Split(path, ".") {
+		if isJSIdent(seg) {
+			b.WriteString("?." + seg)
+		} else {
+			b.WriteString("?.['" + escapeJSString(seg) + "']")
+		}
+	}
+	return b.String()
+}
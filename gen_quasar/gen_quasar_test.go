@@ -0,0 +1,531 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildColumnViewRelationDetection(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantRelation bool
+	}{
+		{"uuid", false},
+		{"grid", false},
+		{"valid", false},
+		{"android", false},
+		{"paid", false},
+		{"id", false},
+		{"user_id", true},
+		{"userId", true},
+		{"ownerID", true},
+		{"v2Id", true},
+	}
+	for _, tt := range tests {
+		cv := buildColumnView(ColumnInfo{Name: tt.name, JSONName: tt.name, Type: "int"}, "id", "/api", nil)
+		if cv.IsRelation != tt.wantRelation {
+			t.Errorf("%s: IsRelation = %v, want %v", tt.name, cv.IsRelation, tt.wantRelation)
+		}
+	}
+
+	// A $ref is a relation only under FK naming; otherwise it is a nested object
+	for _, tt := range tests {
+		if tt.name == "id" {
+			continue
+		}
+		cv := buildColumnView(ColumnInfo{Name: tt.name, JSONName: tt.name, Type: "Category", Ref: "Category"}, "id", "/api", nil)
+		if cv.IsRelation != tt.wantRelation || cv.IsNestedObject == tt.wantRelation {
+			t.Errorf("$ref %s: IsRelation = %v, IsNestedObject = %v, want relation %v", tt.name, cv.IsRelation, cv.IsNestedObject, tt.wantRelation)
+		}
+	}
+}
+
+func TestDetectPrimaryKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   string
+		cols     []ColumnInfo
+		wantPK   string
+		wantAuto bool
+	}{
+		{"uid keyed", "Member", []ColumnInfo{
+			{Name: "Name", JSONName: "name", Type: "string"},
+			{Name: "Uid", JSONName: "uid", Type: "string"},
+		}, "uid", false},
+		{"id wins over uid", "Member", []ColumnInfo{
+			{Name: "Uid", JSONName: "uid", Type: "string"},
+			{Name: "Id", JSONName: "id", Type: "int64"},
+		}, "id", true},
+		{"entity id", "Person", []ColumnInfo{
+			{Name: "Name", JSONName: "name", Type: "string"},
+			{Name: "PersonId", JSONName: "personId", Type: "uint"},
+		}, "personId", true},
+		{"pk hint", "Member", []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int"},
+			{Name: "Code", JSONName: "code", Type: "string", Additional: "pk"},
+		}, "code", false},
+		{"first column", "Setting", []ColumnInfo{
+			{Name: "Key", JSONName: "key", Type: "string"},
+			{Name: "Value", JSONName: "value", Type: "string"},
+		}, "key", false},
+		{"natural integer key", "Country", []ColumnInfo{
+			{Name: "NumericCode", JSONName: "numericCode", Type: "int", Additional: "pk"},
+			{Name: "Name", JSONName: "name", Type: "string"},
+		}, "numericCode", false},
+		{"pk hint marked auto", "Member", []ColumnInfo{
+			{Name: "No", JSONName: "no", Type: "int64", Additional: "pk,auto"},
+		}, "no", true},
+		{"readOnly key", "Token", []ColumnInfo{
+			{Name: "Uuid", JSONName: "uuid", Type: "string", Constraints: &FieldConstraints{ReadOnly: true}},
+		}, "uuid", true},
+		{"non-integer key", "Rate", []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "float64"},
+		}, "id", false},
+	}
+	for _, tt := range tests {
+		pk, auto := detectPrimaryKey(&TableMetadata{NormalizedName: tt.entity, Columns: tt.cols})
+		if pk != tt.wantPK || auto != tt.wantAuto {
+			t.Errorf("%s: detectPrimaryKey = %q, %v; want %q, %v", tt.name, pk, auto, tt.wantPK, tt.wantAuto)
+		}
+	}
+}
+
+func TestToPlural(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Person", "People"},
+		{"person", "people"},
+		{"PERSON", "PEOPLE"},
+		{"Child", "Children"},
+		{"Datum", "Data"},
+		{"Index", "Indices"},
+		{"Sheep", "Sheep"},
+		{"SalesPerson", "SalesPeople"},
+		{"sales_person", "sales_people"},
+		{"Human", "Humans"},
+		{"Category", "Categories"},
+		{"Day", "Days"},
+		{"Box", "Boxes"},
+		{"User", "Users"},
+	}
+	for _, tt := range tests {
+		if got := toPlural(tt.in); got != tt.want {
+			t.Errorf("toPlural(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPlurals(t *testing.T) {
+	saved := maps.Clone(irregularPlurals)
+	t.Cleanup(func() { irregularPlurals = saved })
+
+	path := filepath.Join(t.TempDir(), "plurals.json")
+	if err := os.WriteFile(path, []byte(`{"Cactus": "Cacti", "person": "persons"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadPlurals(path); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct{ in, want string }{
+		{"Cactus", "Cacti"},
+		{"cactus", "cacti"},
+		{"Person", "Persons"},
+		{"Child", "Children"},
+	}
+	for _, tt := range tests {
+		if got := toPlural(tt.in); got != tt.want {
+			t.Errorf("toPlural(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAcronymCasing(t *testing.T) {
+	tests := []struct{ in, human, pascal, camel string }{
+		{"apiUrl", "API URL", "APIURL", "apiURL"},
+		{"userID", "User ID", "UserID", "userID"},
+		{"htmlContent", "HTML Content", "HTMLContent", "htmlContent"},
+		{"HTTPStatus", "HTTP Status", "HTTPStatus", "httpStatus"},
+		{"created_at", "Created At", "CreatedAt", "createdAt"},
+	}
+	for _, tt := range tests {
+		if got := toHuman(tt.in); got != tt.human {
+			t.Errorf("toHuman(%q) = %q, want %q", tt.in, got, tt.human)
+		}
+		if got := toPascal(tt.in); got != tt.pascal {
+			t.Errorf("toPascal(%q) = %q, want %q", tt.in, got, tt.pascal)
+		}
+		if got := toCamel(tt.in); got != tt.camel {
+			t.Errorf("toCamel(%q) = %q, want %q", tt.in, got, tt.camel)
+		}
+	}
+}
+
+func TestLoadNameOverridesAcronyms(t *testing.T) {
+	savedAcronyms, savedOverrides := maps.Clone(acronyms), maps.Clone(nameOverrides)
+	t.Cleanup(func() { acronyms, nameOverrides = savedAcronyms, savedOverrides })
+
+	path := filepath.Join(t.TempDir(), "names.json")
+	if err := os.WriteFile(path, []byte(`{"acronyms": ["sku"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadNameOverrides(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := toHuman("skuCode"); got != "SKU Code" {
+		t.Errorf(`toHuman("skuCode") = %q, want "SKU Code"`, got)
+	}
+	if got := toPascal("skuCode"); got != "SKUCode" {
+		t.Errorf(`toPascal("skuCode") = %q, want "SKUCode"`, got)
+	}
+}
+
+func TestNameOverridePlural(t *testing.T) {
+	saved := maps.Clone(nameOverrides)
+	t.Cleanup(func() { nameOverrides = saved })
+	nameOverrides = map[string]NameOverride{
+		"Staff": {Plural: "Staff", Kebab: "staff", Human: "Staff Member"},
+		"Box":   {Kebab: "crate"},
+	}
+
+	tests := []struct {
+		name, pluralKebab, pluralHuman, apiBase string
+	}{
+		{"Staff", "staff", "Staff", "/api/staff"},
+		{"Box", "crates", "Boxes", "/api/crates"},
+	}
+	for _, tt := range tests {
+		ev := buildEntityView(&TableMetadata{StructName: tt.name, NormalizedName: tt.name}, testOptions(), &ConsolidatedSchema{})
+		if ev.NamePluralKebab != tt.pluralKebab || ev.NamePluralHuman != tt.pluralHuman || ev.APIBasePath != tt.apiBase {
+			t.Errorf("%s: got %q %q %q, want %q %q %q", tt.name, ev.NamePluralKebab, ev.NamePluralHuman, ev.APIBasePath,
+				tt.pluralKebab, tt.pluralHuman, tt.apiBase)
+		}
+	}
+}
+
+// testOptions returns the options main builds from the default flags.
+func testOptions() *GenOptions {
+	return &GenOptions{
+		APIBase:      "/api",
+		OpenAPIURL:   "http://localhost:8000/api.json",
+		OrvalClient:  "vue-query",
+		State:        "vue-query",
+		Currency:     "$",
+		DateFormat:   "YYYY-MM-DD",
+		DetailLayout: "stacked",
+		AuthPath:     "/auth/login",
+		Retries:      3,
+		RetryBaseMs:  300,
+		PageParam:    "page",
+		SizeParam:    "pageSize",
+		SortParam:    "orderBy",
+		OrderParam:   "orderDirection",
+		TotalField:   "total",
+		Pagination:   "offset",
+		UploadURL:    "/api/upload",
+		UploadField:  "data.url",
+	}
+}
+
+// generateFile runs generate on a schema holding only meta and returns the
+// output file at rel (slash-separated, relative to the output directory).
+func generateFile(t *testing.T, meta *TableMetadata, rel string) string {
+	t.Helper()
+	dir := t.TempDir()
+	data, err := json.Marshal(ConsolidatedSchema{
+		Entities:   map[string]*TableMetadata{meta.StructName: meta},
+		EntityList: []*TableMetadata{meta},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaPath := filepath.Join(dir, "schema.logical.json")
+	if err := os.WriteFile(schemaPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(dir, "src-gen")
+	if err := generate(schemaPath, outDir, "", 1, false, testOptions()); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestEmptyFormDefaults(t *testing.T) {
+	meta := &TableMetadata{
+		StructName:     "Article",
+		NormalizedName: "Article",
+		Columns: []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int64"},
+			{Name: "Title", JSONName: "title", Type: "string", Constraints: &FieldConstraints{Default: "Untitled"}},
+			{Name: "Priority", JSONName: "priority", Type: "int", Constraints: &FieldConstraints{Default: 3.0}},
+			{Name: "Published", JSONName: "published", Type: "bool", Constraints: &FieldConstraints{Default: true}},
+			{Name: "Status", JSONName: "status", Type: "string", Constraints: &FieldConstraints{
+				Enum: []string{"draft", "live"}, Default: "live",
+			}},
+			{Name: "Kind", JSONName: "kind", Type: "string", Constraints: &FieldConstraints{
+				Enum: []string{"news", "blog"}, Default: "other",
+			}},
+			{Name: "Slug", JSONName: "slug", Type: "string"},
+		},
+	}
+	form := generateFile(t, meta, "pages/article/FormDialog.vue")
+	for _, want := range []string{
+		"title: 'Untitled',",
+		"priority: 3,",
+		"published: true,",
+		"status: 'live',",
+		"kind: '',", // Not an option: falls back to empty
+		"slug: '',",
+	} {
+		if !strings.Contains(form, "  "+want+"\n") {
+			t.Errorf("emptyForm lacks %q", want)
+		}
+	}
+}
+
+func TestEnumOptions(t *testing.T) {
+	tests := []struct {
+		col         ColumnInfo
+		wantTS      string
+		wantOptions string
+	}{
+		{
+			ColumnInfo{Name: "Level", JSONName: "level", Type: "int", Constraints: &FieldConstraints{
+				Enum: []string{"0", "1", "2"}, EnumLabels: []string{"Low", "Mid", "High"},
+			}},
+			"number",
+			"[{ label: 'Low', value: 0 }, { label: 'Mid', value: 1 }, { label: 'High', value: 2 }]",
+		},
+		{
+			ColumnInfo{Name: "Priority", JSONName: "priority", Type: "integer", Constraints: &FieldConstraints{
+				Enum: []string{"1", "5"},
+			}},
+			"number",
+			"[{ label: '1', value: 1 }, { label: '5', value: 5 }]",
+		},
+		{
+			ColumnInfo{Name: "Status", JSONName: "status", Type: "consts.Status", Constraints: &FieldConstraints{
+				Enum: []string{"active", "on_hold"},
+			}},
+			"string",
+			"[{ label: 'Active', value: 'active' }, { label: 'On Hold', value: 'on_hold' }]",
+		},
+	}
+	for _, tt := range tests {
+		cv := buildColumnView(tt.col, "id", "/api", nil)
+		if !cv.IsEnum || cv.TSType != tt.wantTS || cv.EnumOptions != tt.wantOptions {
+			t.Errorf("%s: got enum=%v %s %s, want %s %s", tt.col.Name, cv.IsEnum, cv.TSType, cv.EnumOptions, tt.wantTS, tt.wantOptions)
+		}
+	}
+
+	// An iota enum starts at 0, which must still render as a chip
+	meta := &TableMetadata{
+		StructName:     "Order",
+		NormalizedName: "Order",
+		Columns: []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int64"},
+			{Name: "Status", JSONName: "status", Type: "int", Constraints: &FieldConstraints{
+				Enum: []string{"0", "1"}, EnumLabels: []string{"Pending", "Paid"},
+			}},
+		},
+	}
+	pages := map[string]string{
+		"pages/order/IndexPage.vue":  `<q-chip v-if="props.value != null && props.value !== ''"`,
+		"pages/order/DetailPage.vue": `<q-chip v-if="item.status != null && item.status !== ''"`,
+	}
+	for rel, guard := range pages {
+		page := generateFile(t, meta, rel)
+		if !strings.Contains(page, guard) {
+			t.Errorf("%s lacks the chip guard %s", rel, guard)
+		}
+		if !strings.Contains(page, "'0': { label: 'Pending'") {
+			t.Errorf("%s lacks the chip for status 0", rel)
+		}
+	}
+}
+
+func TestMergeGvalidInRule(t *testing.T) {
+	tests := []struct {
+		validation   string
+		in           *FieldConstraints
+		wantRequired bool
+		wantEnum     []string
+	}{
+		{`required|in:a,b,c`, nil, true, []string{"a", "b", "c"}},
+		{`in:a,b,c|required`, nil, true, []string{"a", "b", "c"}},
+		{`in: a , b `, nil, false, []string{"a", "b"}},
+		{`in:"on hold",'done'`, nil, false, []string{"on hold", "done"}},
+		{`status@required|in:new,old#Pick a status`, nil, true, []string{"new", "old"}},
+		{`required|in:a,b`, &FieldConstraints{Enum: []string{"x"}}, true, []string{"x"}},
+	}
+	for _, tt := range tests {
+		c := mergeGvalidConstraints(tt.in, tt.validation)
+		if c == nil || c.Required != tt.wantRequired || !slices.Equal(c.Enum, tt.wantEnum) {
+			t.Errorf("%s: got %+v, want required=%v enum=%v", tt.validation, c, tt.wantRequired, tt.wantEnum)
+		}
+	}
+
+	cv := buildColumnView(ColumnInfo{Name: "Role", JSONName: "role", Type: "string", Validation: "required|in:a,b,c"}, "id", "/api", nil)
+	want := "[{ label: 'A', value: 'a' }, { label: 'B', value: 'b' }, { label: 'C', value: 'c' }]"
+	if !cv.IsEnum || !cv.Required || cv.EnumOptions != want {
+		t.Errorf("role: got enum=%v required=%v %s, want a required enum %s", cv.IsEnum, cv.Required, cv.EnumOptions, want)
+	}
+}
+
+func TestNullableFields(t *testing.T) {
+	tests := []struct {
+		col          ColumnInfo
+		wantNullable bool
+	}{
+		{ColumnInfo{Name: "ParentCount", JSONName: "parentCount", Type: "int", Constraints: &FieldConstraints{Nullable: true}}, true},
+		{ColumnInfo{Name: "Rank", JSONName: "rank", Type: "int", Constraints: &FieldConstraints{Nullable: true, Required: true}}, false},
+		{ColumnInfo{Name: "Score", JSONName: "score", Type: "int"}, false},
+		{ColumnInfo{Name: "Verified", JSONName: "verified", Type: "bool", Constraints: &FieldConstraints{Nullable: true}}, false},
+		{ColumnInfo{Name: "Nickname", JSONName: "nickname", Type: "string", Constraints: &FieldConstraints{Nullable: true}}, true},
+	}
+	for _, tt := range tests {
+		if cv := buildColumnView(tt.col, "id", "/api", nil); cv.IsNullable != tt.wantNullable {
+			t.Errorf("%s: IsNullable = %v, want %v", tt.col.Name, cv.IsNullable, tt.wantNullable)
+		}
+	}
+
+	meta := &TableMetadata{
+		StructName:     "Team",
+		NormalizedName: "Team",
+		Columns: []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int64"},
+			{Name: "Name", JSONName: "name", Type: "string", Constraints: &FieldConstraints{Required: true}},
+			{Name: "ParentCount", JSONName: "parentCount", Type: "int", Constraints: &FieldConstraints{Nullable: true}},
+		},
+	}
+	form := generateFile(t, meta, "pages/team/FormDialog.vue")
+	for _, want := range []string{
+		"  parentCount: null,\n",
+		"  if (String(out.parentCount ?? '') === '') out.parentCount = null;\n",
+	} {
+		if !strings.Contains(form, want) {
+			t.Errorf("FormDialog.vue lacks %q", want)
+		}
+	}
+	if types := generateFile(t, meta, "types/Team.ts"); !strings.Contains(types, "  parentCount?: number | null;\n") {
+		t.Errorf("types/Team.ts lacks parentCount?: number | null")
+	}
+}
+
+func TestClearedOptionalNumbers(t *testing.T) {
+	meta := &TableMetadata{
+		StructName:     "Item",
+		NormalizedName: "Item",
+		Columns: []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int64"},
+			{Name: "Quantity", JSONName: "quantity", Type: "int", Constraints: &FieldConstraints{Required: true}},
+			{Name: "Stock", JSONName: "stock", Type: "int"},
+			{Name: "Weight", JSONName: "weight", Type: "float64"},
+			{Name: "Discount", JSONName: "discount", Type: "float64", Constraints: &FieldConstraints{Nullable: true}},
+		},
+	}
+	form := generateFile(t, meta, "pages/item/FormDialog.vue")
+	payload := form[strings.Index(form, "function preparePayload"):]
+	payload = payload[:strings.Index(payload, "\n}\n")]
+
+	// Optional numbers are left out and nullable ones sent as null, never as ''
+	for _, want := range []string{
+		"if (String(out.stock ?? '') === '') delete out.stock;",
+		"if (String(out.weight ?? '') === '') delete out.weight;",
+		"if (String(out.discount ?? '') === '') out.discount = null;",
+	} {
+		if !strings.Contains(payload, want) {
+			t.Errorf("preparePayload lacks %q", want)
+		}
+	}
+	// Required numbers are left to the form rules
+	if strings.Contains(payload, "out.quantity") {
+		t.Errorf("preparePayload touches the required quantity:\n%s", payload)
+	}
+}
+
+func TestPruneKeepsFailedRenders(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema := func(metas ...*TableMetadata) string {
+		schema := ConsolidatedSchema{Entities: map[string]*TableMetadata{}, EntityList: metas}
+		for _, m := range metas {
+			schema.Entities[m.StructName] = m
+		}
+		data, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "schema.logical.json")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	entity := func(name string) *TableMetadata {
+		return &TableMetadata{StructName: name, NormalizedName: name, Columns: []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int64"},
+			{Name: "Title", JSONName: "title", Type: "string"},
+		}}
+	}
+	outDir := filepath.Join(dir, "src-gen")
+	page := filepath.Join(outDir, "pages", "article", "IndexPage.vue")
+	oldTag := filepath.Join(outDir, "pages", "tag", "IndexPage.vue")
+
+	if err := generate(writeSchema(entity("Article"), entity("Tag")), outDir, "", 1, true, testOptions()); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tag is gone, but the index-page override fails to run: nothing may be pruned
+	tplDir := filepath.Join(dir, "templates")
+	writeFile := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(tplDir, "index-page.tmpl"), "[[ .NoSuchField ]]")
+	schemaPath := writeSchema(entity("Article"))
+	if err := generate(schemaPath, outDir, tplDir, 1, true, testOptions()); err != nil {
+		t.Fatal(err)
+	}
+	if after, err := os.ReadFile(page); err != nil || string(after) != string(before) {
+		t.Errorf("IndexPage.vue after a failed render: %v, changed=%v", err, string(after) != string(before))
+	}
+	if _, err := os.Stat(oldTag); err != nil {
+		t.Errorf("a run with failed renders pruned %s: %v", oldTag, err)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, manifestName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"pages/article/IndexPage.vue"`) {
+		t.Errorf("manifest dropped the failed pages/article/IndexPage.vue:\n%s", data)
+	}
+
+	// Once the template renders again, the stale Tag files are pruned
+	if err := os.RemoveAll(tplDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(schemaPath, outDir, "", 1, true, testOptions()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(oldTag); !os.IsNotExist(err) {
+		t.Errorf("%s survived a clean -prune run: %v", oldTag, err)
+	}
+	if _, err := os.Stat(page); err != nil {
+		t.Error(err)
+	}
+}
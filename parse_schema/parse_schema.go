@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -8,12 +9,18 @@ import (
 	"go/parser"
 	"go/token"
 	"io"
+	"maps"
+	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 /*
@@ -47,6 +54,27 @@ type TableMetadata struct {
 	Columns        []ColumnInfo    // Captured fields for full ERD visualization and form generation
 	Relations      []*RelationNode // All discovered 'with' associations
 	Operations     []OperationInfo // OpenAPI operations that can be associated with this logical entity
+
+	FilterParams []FilterParam `json:",omitempty"` // Query parameters of the OpenAPI collection GET
+
+	embeds []embedRef // Anonymous struct fields, inlined by inlineEmbeds once every file is parsed
+	pkg    string     // Go package of the struct; qualifies its unqualified column types for applyGoEnums
+}
+
+// embedRef is an anonymous (embedded) field awaiting inlining: the embedded type
+// and the column index its fields go to.
+type embedRef struct {
+	typeName string
+	at       int
+}
+
+// FilterParam is a query parameter of an entity's collection GET: a list filter
+// the API actually accepts.
+type FilterParam struct {
+	Name     string   // Query parameter name (e.g., "status")
+	Type     string   // Go-ish type name, as in ColumnInfo.Type
+	Enum     []string // Allowed values, if enumerated
+	Required bool
 }
 
 // FieldConstraints captures machine-usable validation/shape constraints.
@@ -61,6 +89,11 @@ type FieldConstraints struct {
 	Pattern   string
 	Format    string
 	Enum      []string
+	ReadOnly  bool // OpenAPI readOnly: server-generated, never sent by clients
+	WriteOnly bool // OpenAPI writeOnly: accepted on input, never returned
+	Default   any  // OpenAPI default value (string, number, bool, ...), nil when absent
+
+	EnumLabels []string `json:",omitempty"` // Display names of integer Enum values, from Go const names (e.g. "Low")
 }
 
 // ColumnInfo represents a non-relational field in the struct (DB Column).
@@ -70,11 +103,24 @@ type ColumnInfo struct {
 	Type        string            // Go-ish type name for diagramming and generator decisions
 	Validation  string            // Gvalid rules (e.g., "required|length:6,30")
 	Description string            // Field description/label (e.g., "User login name")
+	Example     string            // OpenAPI example value, rendered as text (e.g., "jane@example.com")
 	Additional  string            // Extra metadata (e.g., placeholders or custom hints)
 	Constraints *FieldConstraints // OpenAPI-derived constraints
 	Ref         string            // OpenAPI $ref target schema name (if the field is a component reference)
 	IsArray     bool              // True if OpenAPI type is array or Go slice
 	Source      string            // Provenance marker (e.g., "go:do", "go:api", "openapi")
+
+	NestedColumns []ColumnInfo `json:",omitempty"` // Properties of an embedded (non-FK) object $ref, or of its array items
+
+	Discriminator string        `json:",omitempty"` // Property telling the Variants of a oneOf/anyOf apart
+	Variants      []VariantInfo `json:",omitempty"` // Branches of a discriminated oneOf/anyOf, in schema order
+}
+
+// VariantInfo is one branch of a discriminated oneOf/anyOf property.
+type VariantInfo struct {
+	Name    string       // Component schema name of the branch (e.g., "CardPayment")
+	Value   string       // Discriminator value selecting it (mapping key, else Name)
+	Columns []ColumnInfo // Properties of the branch
 }
 
 // RelationNode defines a single relationship between two tables.
@@ -87,6 +133,22 @@ type RelationNode struct {
 	SourceKey    string `json:"source_key"`    // The PK on the local table (the 'id' in 'uid=id')
 	Validation   string `json:"validation"`    // Relation-specific validation
 	Description  string `json:"description"`   // Relation-specific description
+	// IsSelfReference marks a tree relation whose target is the owning struct
+	// (e.g. Category.Children with:pid=id).
+	IsSelfReference bool `json:"is_self_reference"`
+	// ThroughTable and ThroughKeys describe an M2M relation joined through an
+	// intermediate table: ThroughKeys[0] maps the join table to this struct
+	// (user_tags.user_id=id), ThroughKeys[1] the target to the join table
+	// (id=user_tags.tag_id). Empty for direct relations.
+	ThroughTable string         `json:"through_table,omitempty"`
+	ThroughKeys  []RelationKeys `json:"through_keys,omitempty"`
+}
+
+// RelationKeys is one key=key pair of a with tag: TargetKey on the joined side,
+// SourceKey on the joining side.
+type RelationKeys struct {
+	TargetKey string `json:"target_key"`
+	SourceKey string `json:"source_key"`
 }
 
 // OperationInfo is a minimal OpenAPI operation descriptor used by UI generators.
@@ -98,7 +160,8 @@ type OperationInfo struct {
 	Tags           []string `json:"tags"`
 	RequestSchema  string   `json:"request_schema"`
 	ResponseSchema string   `json:"response_schema"`
-	Source         string   `json:"source"` // "openapi"
+	Source         string   `json:"source"`        // "openapi"
+	RequiresAuth   bool     `json:"requires_auth"` // Effective security (own, else global) demands credentials
 }
 
 // ConsolidatedSchema is a generator-friendly container that provides both
@@ -113,15 +176,39 @@ func main() {
 	// CONFIGURATION: Adjust searchRoot to match your project structure.
 	// Common GoFrame paths: "./internal/model/do" or "./internal" to include api/
 	var (
-		searchRoot  = flag.String("root", "./internal", "Root directory to scan for GoFrame structs (internal/...)")
-		openapiPath = flag.String("openapi", "", "Path to OpenAPI v3 JSON (optional)")
-		rawOutPath  = flag.String("raw-out", "", "Write raw (unconsolidated) schema JSON (optional)")
-		outPath     = flag.String("out", "schema.logical.json", "Write consolidated schema JSON")
+		searchRoot = flag.String("root", "./internal", "Root directory to scan for GoFrame structs (internal/...)")
+		rawOutPath = flag.String("raw-out", "", "Write raw (unconsolidated) schema JSON (optional)")
+		outPath    = flag.String("out", "schema.logical.json", "Write consolidated schema JSON")
+		diagram    = flag.String("diagram", "er", "Mermaid diagram to print: er, class or both")
+		diagramFmt = flag.String("diagram-format", "mermaid", "Diagram format: mermaid or dot (Graphviz; ignores -diagram)")
+		diagramOut = flag.String("diagram-out", "", "Write the diagram to this .mmd/.dot file instead of stdout (optional)")
+		diagramMD  = flag.Bool("diagram-md", false, "Wrap the diagram in a ```mermaid (or ```dot) fence for Markdown")
+		apiToken   = flag.String("openapi-token", "", "Bearer token sent when -openapi is an http(s):// URL (optional)")
 	)
+	// Several specs (one per service) are merged; each resolves $ref in its own components
+	var openapiPaths []string
+	flag.Func("openapi", "Path or http(s):// URL of an OpenAPI v3 JSON/YAML spec (optional); repeat or comma-separate to merge several", func(v string) error {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" && !slices.Contains(openapiPaths, p) {
+				openapiPaths = append(openapiPaths, p)
+			}
+		}
+		return nil
+	})
 	flag.Parse()
 
+	if *diagram != "er" && *diagram != "class" && *diagram != "both" {
+		fmt.Printf("❌ Invalid -diagram %q (want er, class or both)\n", *diagram)
+		os.Exit(1)
+	}
+	if *diagramFmt != "mermaid" && *diagramFmt != "dot" {
+		fmt.Printf("❌ Invalid -diagram-format %q (want mermaid or dot)\n", *diagramFmt)
+		os.Exit(1)
+	}
+
 	schema := make(SchemaMap)
 
+	enums := newGoEnums()
 	fmt.Printf("🔍 Scanning %s for GoFrame 'do' models and API structs...\n", *searchRoot)
 
 	err := filepath.Walk(*searchRoot, func(path string, info os.FileInfo, err error) error {
@@ -136,32 +223,67 @@ func main() {
 		// Scan both model/do and api directories (common in GoFrame projects).
 		pathSlash := filepath.ToSlash(path)
 		if !strings.Contains(pathSlash, "/model/do") && !strings.Contains(pathSlash, "/api") {
+			// Typed const groups (enums) often live elsewhere, e.g. internal/consts
+			if !strings.HasSuffix(path, "_test.go") {
+				parseEnumFile(path, enums)
+			}
 			return nil
 		}
 
-		parseFile(path, schema)
+		parseFile(path, schema, enums)
 		return nil
 	})
 	if err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
 		os.Exit(1)
 	}
+	inlineEmbeds(schema)
+	applyGoEnums(schema, enums)
 
-	if *openapiPath != "" {
-		fmt.Printf("📦 Loading OpenAPI: %s\n", *openapiPath)
-		openapiSchema, err := parseOpenAPIFile(*openapiPath)
+	// Name clashes across specs are kept apart by putSchema (User, User__2) and
+	// merged again by normalized name on consolidation
+	for _, path := range openapiPaths {
+		fmt.Printf("📦 Loading OpenAPI: %s\n", path)
+		openapiSchema, err := parseOpenAPIFile(path, *apiToken)
 		if err != nil {
-			fmt.Printf("❌ OpenAPI error: %v\n", err)
+			fmt.Printf("❌ OpenAPI error in %s: %v\n", path, err)
 			os.Exit(1)
 		}
+		ops := 0
 		for _, meta := range openapiSchema {
+			ops += len(meta.Operations)
 			putSchema(schema, meta)
 		}
+		fmt.Printf("   %d schemas, %d operations\n", len(openapiSchema), ops)
 	}
 
 	printSchemaSummary(schema)
-	// Generate and print Mermaid ER Diagram for visualization.
-	fmt.Println(generateERDiagram(schema))
+	// Generate diagrams for visualization: to -diagram-out, else stdout.
+	var diagrams []string
+	if *diagramFmt == "dot" {
+		diagrams = append(diagrams, generateDOTDiagram(schema))
+	} else {
+		if *diagram == "er" || *diagram == "both" {
+			diagrams = append(diagrams, generateERDiagram(schema))
+		}
+		if *diagram == "class" || *diagram == "both" {
+			diagrams = append(diagrams, generateClassDiagram(schema))
+		}
+	}
+	fence := ""
+	if *diagramMD {
+		fence = *diagramFmt
+	}
+	diagramText := joinDiagrams(diagrams, fence)
+	if *diagramOut != "" {
+		if err := os.WriteFile(*diagramOut, []byte(diagramText), 0o644); err != nil {
+			fmt.Printf("❌ Error writing diagram: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("📝 Diagram written to %s\n", *diagramOut)
+	} else {
+		fmt.Print(diagramText)
+	}
 
 	if *rawOutPath != "" {
 		if err := writeJSONFile(*rawOutPath, schema); err != nil {
@@ -239,13 +361,14 @@ func putSchema(schema SchemaMap, table *TableMetadata) {
 }
 
 // parseFile uses the go/ast package to read source code without executing it.
-func parseFile(path string, schema SchemaMap) {
+func parseFile(path string, schema SchemaMap, enums *goEnums) {
 	fset := token.NewFileSet()
 	node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
 	if err != nil {
 		fmt.Printf("⚠️ Skipping %s: %v\n", path, err)
 		return
 	}
+	collectEnums(node, enums)
 
 	fileSource := sourceFromPath(path)
 
@@ -266,11 +389,13 @@ func parseFile(path string, schema SchemaMap) {
 			Relations:      []*RelationNode{},
 			Columns:        []ColumnInfo{},
 			Operations:     []OperationInfo{},
+			pkg:            node.Name.Name,
 		}
 
 		for _, field := range structType.Fields.List {
 			typeName, isCollection := resolveTypeInfo(field.Type)
 			var vTag, dcTag, adTag, jsonTag string
+			var jsonSkip bool
 
 			if field.Tag != nil {
 				unquoted, err := strconv.Unquote(field.Tag.Value)
@@ -282,6 +407,7 @@ func parseFile(path string, schema SchemaMap) {
 					dcTag = tags.Get("dc")
 					adTag = tags.Get("ad")
 					jsonTag = parseJSONTag(tags.Get("json"))
+					jsonSkip = tags.Get("json") == "-"
 
 					if strings.Contains(ormTag, "with:") {
 						rel := parseWithTag(ormTag)
@@ -291,6 +417,7 @@ func parseFile(path string, schema SchemaMap) {
 							}
 							rel.TargetStruct = typeName
 							rel.IsCollection = isCollection
+							rel.IsSelfReference = isSelfReference(typeName, table.StructName)
 							rel.Validation = vTag
 							rel.Description = dcTag
 							table.Relations = append(table.Relations, rel)
@@ -300,29 +427,294 @@ func parseFile(path string, schema SchemaMap) {
 				}
 			}
 
-			// Capture standard fields with validation and description metadata
-			if len(field.Names) > 0 {
-				table.Columns = append(table.Columns, ColumnInfo{
-					Name:        field.Names[0].Name,
-					JSONName:    jsonTag,
-					Type:        typeName,
-					Validation:  vTag,
-					Description: dcTag,
-					Additional:  adTag,
-					IsArray:     isCollection,
-					Source:      fileSource,
-				})
+			// json:"-" fields never reach the API payload, so they are not columns
+			if jsonSkip {
+				continue
 			}
+
+			// Embedded structs: g.Meta only carries route/table metadata; anything else
+			// is inlined after the walk, when structs from every file are known
+			if len(field.Names) == 0 {
+				if typeName != "g.Meta" && typeName != "gmeta.Meta" {
+					table.embeds = append(table.embeds, embedRef{typeName: typeName, at: len(table.Columns)})
+				}
+				continue
+			}
+
+			// Capture standard fields with validation and description metadata
+			table.Columns = append(table.Columns, ColumnInfo{
+				Name:        field.Names[0].Name,
+				JSONName:    jsonTag,
+				Type:        typeName,
+				Validation:  vTag,
+				Description: dcTag,
+				Additional:  adTag,
+				IsArray:     isCollection,
+				Source:      fileSource,
+			})
 		}
 
 		// Always track the table if it has fields or relations
-		if len(table.Columns) > 0 || len(table.Relations) > 0 {
+		if len(table.Columns) > 0 || len(table.Relations) > 0 || len(table.embeds) > 0 {
 			putSchema(schema, table)
 		}
 		return true
 	})
 }
 
+// inlineEmbeds copies the columns and relations of embedded structs into the
+// structs embedding them, at the embedding position (recursively, so a Base that
+// embeds Timestamps contributes both). Embeds that were not scanned, e.g. from
+// third-party packages, are reported as notes and skipped. Embedded structs are
+// then removed from the schema unless a relation targets them: they are building
+// blocks of entities, not entities of their own.
+func inlineEmbeds(schema SchemaMap) {
+	byName := make(map[string]*TableMetadata, len(schema))
+	for _, meta := range sortedMetas(schema) {
+		if _, dup := byName[meta.StructName]; !dup {
+			byName[meta.StructName] = meta
+		}
+	}
+
+	embedded := make(map[string]bool)
+	var resolve func(meta *TableMetadata, stack map[string]bool)
+	resolve = func(meta *TableMetadata, stack map[string]bool) {
+		embeds := meta.embeds
+		meta.embeds = nil
+		stack[meta.StructName] = true
+		defer delete(stack, meta.StructName)
+
+		// Back to front, so earlier insert positions stay valid
+		for i := len(embeds) - 1; i >= 0; i-- {
+			e := embeds[i]
+			name := e.typeName
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				name = name[idx+1:]
+			}
+			base := byName[name]
+			switch {
+			case base == nil:
+				fmt.Printf("ℹ️  %s embeds %s, which was not scanned; its fields are not included\n", meta.StructName, e.typeName)
+				continue
+			case stack[name]:
+				fmt.Printf("⚠️ %s embeds %s cyclically; skipping\n", meta.StructName, e.typeName)
+				continue
+			}
+			resolve(base, stack)
+			meta.Columns = slices.Insert(meta.Columns, e.at, base.Columns...)
+			meta.Relations = append(meta.Relations, base.Relations...)
+			embedded[name] = true
+		}
+	}
+	for _, meta := range sortedMetas(schema) {
+		if len(meta.embeds) > 0 {
+			resolve(meta, map[string]bool{})
+		}
+	}
+
+	targets := make(map[string]bool)
+	for _, meta := range schema {
+		for _, rel := range meta.Relations {
+			target := rel.TargetStruct
+			if idx := strings.LastIndex(target, "."); idx != -1 {
+				target = target[idx+1:]
+			}
+			targets[target] = true
+		}
+	}
+	for key, meta := range schema {
+		if embedded[meta.StructName] && !targets[meta.StructName] {
+			delete(schema, key)
+		}
+	}
+}
+
+// goEnums collects typed const groups across files: named string/integer types
+// and the constants declared for them, matched to columns once every file is parsed.
+// Types are keyed by package-qualified name, so consts.Status and order.Status stay apart.
+type goEnums struct {
+	types  map[string]string        // e.g. "consts.Status" -> "string" for `type Status string`
+	values map[string][]goEnumValue // qualified type name -> consts in declaration order
+}
+
+// goEnumValue is one typed constant: its identifier and value in string form.
+type goEnumValue struct {
+	name, value string
+}
+
+func newGoEnums() *goEnums {
+	return &goEnums{types: map[string]string{}, values: map[string][]goEnumValue{}}
+}
+
+// parseEnumFile collects enums from a file that holds no models.
+func parseEnumFile(path string, enums *goEnums) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		fmt.Printf("⚠️ Skipping %s: %v\n", path, err)
+		return
+	}
+	collectEnums(node, enums)
+}
+
+// collectEnums records `type X string|int...` declarations and the values of
+// const groups typed as such, including Status("x") conversions and iota
+// sequences (an untyped or omitted expression repeats the previous one).
+// Unqualified type names are qualified with the file's package.
+func collectEnums(file *ast.File, enums *goEnums) {
+	pkg := file.Name.Name
+	qualify := func(expr ast.Expr) string {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			return pkg + "." + t.Name
+		case *ast.SelectorExpr:
+			if x, ok := t.X.(*ast.Ident); ok {
+				return x.Name + "." + t.Sel.Name
+			}
+		}
+		return ""
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		switch gen.Tok {
+		case token.TYPE:
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Assign.IsValid() {
+					continue
+				}
+				if base, ok := ts.Type.(*ast.Ident); ok && isEnumBaseType(base.Name) {
+					enums.types[pkg+"."+ts.Name.Name] = base.Name
+				}
+			}
+		case token.CONST:
+			var typeName string
+			var exprs []ast.Expr
+			for iota, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				if vs.Type != nil || len(vs.Values) > 0 {
+					typeName = qualify(vs.Type)
+					exprs = vs.Values
+				}
+				for i, name := range vs.Names {
+					if name.Name == "_" || i >= len(exprs) {
+						continue
+					}
+					typ, expr := typeName, exprs[i]
+					if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+						if fn := qualify(call.Fun); fn != "" {
+							typ, expr = fn, call.Args[0]
+						}
+					}
+					if typ == "" {
+						continue
+					}
+					if v, ok := constValue(expr, iota); ok {
+						enums.values[typ] = append(enums.values[typ], goEnumValue{name: name.Name, value: v})
+					}
+				}
+			}
+		}
+	}
+}
+
+func isEnumBaseType(name string) bool {
+	return name == "string" || strings.HasPrefix(name, "int") || strings.HasPrefix(name, "uint")
+}
+
+// constValue evaluates a string literal or an integer expression (literals, iota,
+// + - * << and parentheses) to its string form.
+func constValue(expr ast.Expr, iota int) (string, bool) {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		s, err := strconv.Unquote(lit.Value)
+		return s, err == nil
+	}
+	n, ok := constInt(expr, iota)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatInt(n, 10), true
+}
+
+func constInt(expr ast.Expr, iota int) (int64, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return 0, false
+		}
+		n, err := strconv.ParseInt(e.Value, 0, 64)
+		return n, err == nil
+	case *ast.Ident:
+		return int64(iota), e.Name == "iota"
+	case *ast.ParenExpr:
+		return constInt(e.X, iota)
+	case *ast.BinaryExpr:
+		x, okX := constInt(e.X, iota)
+		y, okY := constInt(e.Y, iota)
+		if !okX || !okY {
+			return 0, false
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		case token.MUL:
+			return x * y, true
+		case token.SHL:
+			return x << y, true
+		}
+	}
+	return 0, false
+}
+
+// applyGoEnums fills Constraints.Enum of scalar columns typed with a collected
+// enum type (e.g. Status in the model's own package, or consts.Status). Columns
+// already listing enum values keep them. Integer enums also set the column Type
+// to their base type, so generators keep the values numeric, and label the
+// values with their const names minus the type prefix (LevelLow -> "Low").
+func applyGoEnums(schema SchemaMap, enums *goEnums) {
+	for _, meta := range schema {
+		for i := range meta.Columns {
+			col := &meta.Columns[i]
+			name := col.Type
+			if !strings.Contains(name, ".") {
+				name = meta.pkg + "." + name
+			}
+			base, values := enums.types[name], enums.values[name]
+			if col.IsArray || base == "" || len(values) == 0 {
+				continue
+			}
+			if col.Constraints == nil {
+				col.Constraints = &FieldConstraints{}
+			}
+			if len(col.Constraints.Enum) > 0 {
+				continue
+			}
+			typeName := name[strings.LastIndex(name, ".")+1:]
+			for _, v := range values {
+				col.Constraints.Enum = append(col.Constraints.Enum, v.value)
+				if base != "string" {
+					label := strings.TrimPrefix(v.name, typeName)
+					if label == "" {
+						label = v.name
+					}
+					col.Constraints.EnumLabels = append(col.Constraints.EnumLabels, label)
+				}
+			}
+			if base != "string" {
+				col.Type = base
+			}
+		}
+	}
+}
+
 func parseJSONTag(tag string) string {
 	if tag == "" {
 		return ""
@@ -365,35 +757,77 @@ func resolveTypeInfo(expr ast.Expr) (name string, isCollection bool) {
 // parseWithTag implements professional parsing for the orm:"with:..." syntax.
 // with deterministic, allocation-friendly string splitting.
 // Reference: mysql_z_unit_feature_with_test.go coverage
+//
+// A chain of two with segments is a many-to-many relation through a join table,
+// named by a table qualifier on either key or by a table: segment:
+//
+//	Tags []*Tag `orm:"with:user_tags.user_id=id, with:id=user_tags.tag_id"`
+//	Tags []*Tag `orm:"table:user_tags, with:user_id=id, with:id=tag_id"`
 func parseWithTag(tag string) *RelationNode {
-	// 1. Isolate the 'with' segment if multiple orm segments exist (comma separated)
+	// 1. Isolate the 'with' segments if multiple orm segments exist (comma separated)
 	parts := strings.Split(tag, ",")
-	var withPart string
+	var withParts []string
+	var table string
 	for _, p := range parts {
 		p = strings.TrimSpace(p)
 		if strings.HasPrefix(p, "with:") {
-			withPart = strings.TrimPrefix(p, "with:")
-			break
+			withParts = append(withParts, strings.TrimPrefix(p, "with:"))
+		} else if strings.HasPrefix(p, "table:") {
+			table = strings.TrimSpace(strings.TrimPrefix(p, "table:"))
 		}
 	}
 
-	if withPart == "" {
+	if len(withParts) == 0 {
 		return nil
 	}
 
 	rel := &RelationNode{}
+	rel.TargetKey, rel.SourceKey = parseKeyPair(withParts[0])
+	if len(withParts) == 1 {
+		return rel
+	}
+
+	// 2. Through relation: strip join-table qualifiers off the keys of both pairs
+	for _, w := range withParts[:2] {
+		target, source := parseKeyPair(w)
+		var qualifier string
+		if q, key, ok := strings.Cut(target, "."); ok {
+			qualifier, target = q, key
+		}
+		if q, key, ok := strings.Cut(source, "."); ok {
+			qualifier, source = q, key
+		}
+		if rel.ThroughTable == "" {
+			rel.ThroughTable = qualifier
+		}
+		rel.ThroughKeys = append(rel.ThroughKeys, RelationKeys{TargetKey: target, SourceKey: source})
+	}
+	if rel.ThroughTable == "" {
+		rel.ThroughTable = table
+	}
+	rel.TargetKey, rel.SourceKey = rel.ThroughKeys[0].TargetKey, rel.ThroughKeys[0].SourceKey
+	return rel
+}
 
-	// Use SplitN for deterministic extraction of Target/Source keys.
-	// Handles "uid=id", "uid = id", and implicit "uid" cases cleanly with trimming.
-	kv := strings.SplitN(withPart, "=", 2)
-	rel.TargetKey = strings.TrimSpace(kv[0])
+// parseKeyPair splits one with segment into its target and source keys.
+// Use SplitN for deterministic extraction of Target/Source keys.
+// Handles "uid=id", "uid = id", and implicit "uid" cases cleanly with trimming.
+func parseKeyPair(with string) (target, source string) {
+	kv := strings.SplitN(with, "=", 2)
+	target = strings.TrimSpace(kv[0])
 	if len(kv) > 1 {
-		rel.SourceKey = strings.TrimSpace(kv[1])
-	} else {
-		rel.SourceKey = "id" // GoFrame default
+		return target, strings.TrimSpace(kv[1])
 	}
+	return target, "id" // GoFrame default
+}
 
-	return rel
+// isSelfReference reports whether a relation targets its own struct, ignoring the
+// package qualifier (a do.Category field of type entity.Category is still a tree).
+func isSelfReference(target, owner string) bool {
+	if idx := strings.LastIndex(target, "."); idx != -1 {
+		target = target[idx+1:]
+	}
+	return target == owner
 }
 
 func printSchemaSummary(schema SchemaMap) {
@@ -421,8 +855,16 @@ func printSchemaSummary(schema SchemaMap) {
 			if rel.IsCollection {
 				kind = "1:N"
 			}
-			fmt.Printf("  └─ [%s] %-12s -> %-15s (Map: %s=%s)\n",
-				kind, rel.FieldName, rel.TargetStruct, rel.TargetKey, rel.SourceKey)
+			self := ""
+			if rel.IsSelfReference {
+				self = ", self"
+			}
+			if rel.ThroughTable != "" {
+				kind = "N:M"
+				self += ", via " + rel.ThroughTable
+			}
+			fmt.Printf("  └─ [%s] %-12s -> %-15s (Map: %s=%s%s)\n",
+				kind, rel.FieldName, rel.TargetStruct, rel.TargetKey, rel.SourceKey, self)
 		}
 	}
 }
@@ -438,18 +880,22 @@ func generateERDiagram(schema SchemaMap) string {
 	sb.WriteString("erDiagram\n")
 
 	// 1. Define entities and their attributes
-	metas := make([]*TableMetadata, 0, len(schema))
-	for _, meta := range schema {
-		metas = append(metas, meta)
-	}
-	sort.Slice(metas, func(i, j int) bool { return metas[i].StructName < metas[j].StructName })
+	metas := sortedMetas(schema)
 
 	for _, meta := range metas {
 		sb.WriteString(fmt.Sprintf("    %s {\n", meta.StructName))
+		pk, _ := detectPrimaryKey(meta)
 		for _, col := range meta.Columns {
 			// Mermaid types cannot contain special characters like '.' or '*'
 			cleanType := strings.ReplaceAll(col.Type, ".", "_")
-			sb.WriteString(fmt.Sprintf("        %s %s\n", cleanType, col.Name))
+			line := fmt.Sprintf("        %s %s", cleanType, col.Name)
+			if keys := columnKeys(col, pk); keys != "" {
+				line += " " + keys
+			}
+			if col.Constraints != nil && col.Constraints.Required {
+				line += ` "required"`
+			}
+			sb.WriteString(line + "\n")
 		}
 		sb.WriteString("    }\n\n")
 	}
@@ -462,6 +908,9 @@ func generateERDiagram(schema SchemaMap) string {
 			if rel.IsCollection {
 				cardinality = "||--o{"
 			}
+			if rel.ThroughTable != "" {
+				cardinality = "}o--o{"
+			}
 
 			// Extract target name without package for Mermaid alias matching
 			target := rel.TargetStruct
@@ -470,6 +919,13 @@ func generateERDiagram(schema SchemaMap) string {
 			}
 
 			label := fmt.Sprintf(`"%s (%s=%s)"`, rel.FieldName, rel.TargetKey, rel.SourceKey)
+			if rel.IsSelfReference {
+				// Tree relation: say so, the loop alone is easy to misread
+				label = fmt.Sprintf(`"%s (%s=%s, self)"`, rel.FieldName, rel.TargetKey, rel.SourceKey)
+			}
+			if rel.ThroughTable != "" {
+				label = fmt.Sprintf(`"%s (via %s)"`, rel.FieldName, rel.ThroughTable)
+			}
 			sb.WriteString(fmt.Sprintf("    %s %s %s : %s\n", meta.StructName, cardinality, target, label))
 		}
 	}
@@ -477,6 +933,305 @@ func generateERDiagram(schema SchemaMap) string {
 	return sb.String()
 }
 
+// columnKeys returns the Mermaid key markers of a column: "PK", "FK", "PK, FK" or "".
+// Foreign keys are named like user_id/userId or reference another schema that is
+// not embedded; a primary key named like that ({entity}_id) is only a foreign key
+// when it references another schema.
+func columnKeys(col ColumnInfo, pk string) string {
+	var keys []string
+	name := columnJSONName(col)
+	isPK := name == pk
+	if isPK {
+		keys = append(keys, "PK")
+	}
+	isRef := col.Ref != "" && len(col.NestedColumns) == 0
+	if isRef || (isForeignKeyName(name) && !isPK) {
+		keys = append(keys, "FK")
+	}
+	return strings.Join(keys, ", ")
+}
+
+// detectPrimaryKey picks the entity's key column and reports whether it is assigned
+// by the database: an integer key, unless it carries the pk hint without "auto",
+// or any key hinted ad:"auto" or readOnly. An ad:"pk" hint (OpenAPI x-primary-key) wins; then "id",
+// "{entity}_id"/"{entity}Id", "uid"/"uuid", any integer column containing "id",
+// and finally the first column.
+//
+// This is gen_quasar's detectPrimaryKey, copied with its helpers below (columnJSONName,
+// hasHint, splitWords, toSnake) so diagrams and the generated UI agree on the key.
+// The two tools are separate main packages; change both copies together.
+func detectPrimaryKey(meta *TableMetadata) (string, bool) {
+	cols := meta.Columns
+	isInt := func(c ColumnInfo) bool {
+		return !c.IsArray && strings.Contains(strings.ToLower(c.Type), "int")
+	}
+	// An explicit key is a natural one unless marked auto or readOnly
+	isAuto := func(c ColumnInfo) bool {
+		if hasHint(c.Additional, "auto") || c.Constraints != nil && c.Constraints.ReadOnly {
+			return true
+		}
+		return isInt(c) && !hasHint(c.Additional, "pk")
+	}
+	find := func(match func(c ColumnInfo) bool) (string, bool) {
+		for _, c := range cols {
+			if match(c) {
+				return columnJSONName(c), isAuto(c)
+			}
+		}
+		return "", false
+	}
+
+	entity := strings.ToLower(toSnake(meta.NormalizedName))
+	candidates := []func(c ColumnInfo) bool{
+		func(c ColumnInfo) bool { return hasHint(c.Additional, "pk") },
+		func(c ColumnInfo) bool { return strings.EqualFold(columnJSONName(c), "id") },
+		func(c ColumnInfo) bool {
+			return entity != "" && strings.ToLower(toSnake(columnJSONName(c))) == entity+"_id"
+		},
+		func(c ColumnInfo) bool {
+			name := strings.ToLower(columnJSONName(c))
+			return name == "uid" || name == "uuid"
+		},
+		func(c ColumnInfo) bool {
+			return strings.Contains(strings.ToLower(columnJSONName(c)), "id") && isInt(c)
+		},
+	}
+	for _, match := range candidates {
+		if pk, auto := find(match); pk != "" {
+			return pk, auto
+		}
+	}
+	if len(cols) > 0 {
+		return columnJSONName(cols[0]), isAuto(cols[0])
+	}
+	return "id", true
+}
+
+// columnJSONName is the wire name of a column, falling back to the Go field name.
+func columnJSONName(col ColumnInfo) string {
+	if col.JSONName == "" {
+		return col.Name // Preserve GoFrame's actual field name
+	}
+	return col.JSONName
+}
+
+// hasHint reports whether the column's "ad" tag carries the given directive keyword.
+// Directives are separated by commas, pipes or whitespace (e.g. ad:"list,richtext").
+func hasHint(additional, keyword string) bool {
+	for _, f := range strings.FieldsFunc(additional, func(r rune) bool {
+		return r == ',' || r == '|' || unicode.IsSpace(r)
+	}) {
+		if strings.EqualFold(f, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) {
+				flush()
+			} else if unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+func toSnake(s string) string {
+	words := splitWords(s)
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+	return strings.Join(words, "_")
+}
+
+// joinDiagrams separates diagrams with a blank line, each fenced as ```{fence} when
+// fence is set so the text can be pasted into Markdown.
+func joinDiagrams(diagrams []string, fence string) string {
+	var sb strings.Builder
+	for i, d := range diagrams {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		d = strings.TrimRight(d, "\n") + "\n"
+		if fence != "" {
+			d = "```" + fence + "\n" + d + "```\n"
+		}
+		sb.WriteString(d)
+	}
+	return sb.String()
+}
+
+// generateClassDiagram produces a Mermaid.js class diagram: columns as attributes,
+// OpenAPI operations as methods and relations as associations with multiplicity.
+func generateClassDiagram(schema SchemaMap) string {
+	if len(schema) == 0 {
+		return "classDiagram\n  %% No entities found"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("classDiagram\n")
+
+	metas := sortedMetas(schema)
+	for _, meta := range metas {
+		sb.WriteString(fmt.Sprintf("    class %s {\n", meta.StructName))
+		for _, col := range meta.Columns {
+			sb.WriteString(fmt.Sprintf("        +%s %s\n", classDiagramType(col.Type), col.Name))
+		}
+		// Operations arrive in map iteration order; sort them so the output is stable
+		ops := slices.Clone(meta.Operations)
+		sort.SliceStable(ops, func(i, j int) bool {
+			if ops[i].Path != ops[j].Path {
+				return ops[i].Path < ops[j].Path
+			}
+			return ops[i].Method < ops[j].Method
+		})
+		for _, op := range ops {
+			name := op.OperationID
+			if name == "" {
+				name = strings.ToLower(op.Method)
+			}
+			// Braces would close the class body: render /users/{id} as /users/:id
+			path := strings.NewReplacer("{", ":", "}", "").Replace(op.Path)
+			sb.WriteString(fmt.Sprintf("        +%s() %s %s\n", name, op.Method, path))
+		}
+		sb.WriteString("    }\n\n")
+	}
+
+	for _, meta := range metas {
+		for _, rel := range meta.Relations {
+			multiplicity := `"1"`
+			if rel.IsCollection {
+				multiplicity = `"*"`
+			}
+			source := `"1"`
+			if rel.ThroughTable != "" {
+				source = `"*"`
+			}
+			target := rel.TargetStruct
+			if idx := strings.LastIndex(target, "."); idx != -1 {
+				target = target[idx+1:]
+			}
+			sb.WriteString(fmt.Sprintf("    %s %s --> %s %s : %s\n", meta.StructName, source, multiplicity, target, rel.FieldName))
+		}
+	}
+
+	return sb.String()
+}
+
+// generateDOTDiagram produces a Graphviz digraph: one record node per entity
+// listing its columns (with PK/FK markers) and one edge per relation labelled
+// with the field name and cardinality.
+func generateDOTDiagram(schema SchemaMap) string {
+	var sb strings.Builder
+	sb.WriteString("digraph schema {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString("    node [shape=record, fontname=\"Helvetica\", fontsize=10];\n")
+	sb.WriteString("    edge [fontname=\"Helvetica\", fontsize=9];\n")
+	if len(schema) == 0 {
+		sb.WriteString("    // No entities found\n}\n")
+		return sb.String()
+	}
+	sb.WriteString("\n")
+
+	metas := sortedMetas(schema)
+	for _, meta := range metas {
+		pk, _ := detectPrimaryKey(meta)
+		var fields strings.Builder
+		for _, col := range meta.Columns {
+			field := col.Name + " : " + col.Type
+			if keys := columnKeys(col, pk); keys != "" {
+				field += " (" + keys + ")"
+			}
+			if col.Constraints != nil && col.Constraints.Required {
+				field += " *"
+			}
+			fields.WriteString(dotRecordEscape(field) + `\l`)
+		}
+		sb.WriteString(fmt.Sprintf("    %q [label=\"{%s|%s}\"];\n",
+			meta.StructName, dotRecordEscape(meta.StructName), fields.String()))
+	}
+
+	sb.WriteString("\n")
+	for _, meta := range metas {
+		for _, rel := range meta.Relations {
+			cardinality := "1:1"
+			if rel.IsCollection {
+				cardinality = "1:N"
+			}
+			if rel.ThroughTable != "" {
+				cardinality = "N:M"
+			}
+			target := rel.TargetStruct
+			if idx := strings.LastIndex(target, "."); idx != -1 {
+				target = target[idx+1:]
+			}
+			label := fmt.Sprintf("%s %s (%s=%s)", rel.FieldName, cardinality, rel.TargetKey, rel.SourceKey)
+			if rel.IsSelfReference {
+				label = fmt.Sprintf("%s %s (%s=%s, self)", rel.FieldName, cardinality, rel.TargetKey, rel.SourceKey)
+			}
+			if rel.ThroughTable != "" {
+				label = fmt.Sprintf("%s %s (via %s)", rel.FieldName, cardinality, rel.ThroughTable)
+			}
+			sb.WriteString(fmt.Sprintf("    %q -> %q [label=%q];\n", meta.StructName, target, label))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotRecordEscape escapes the characters that structure a Graphviz record label
+// ({ } | < >), plus quotes and backslashes of the surrounding string.
+func dotRecordEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`,
+		"{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`,
+	).Replace(s)
+}
+
+// classDiagramType renders a Go-ish type for a Mermaid class attribute: slices as
+// List~T~ generics, package dots and pointers dropped.
+func classDiagramType(t string) string {
+	t = strings.NewReplacer(".", "_", "*", "").Replace(t)
+	if elem, ok := strings.CutPrefix(t, "[]"); ok {
+		return "List~" + elem + "~"
+	}
+	return t
+}
+
+// sortedMetas returns the schema's entities ordered by struct name, so diagrams
+// are stable across runs.
+func sortedMetas(schema SchemaMap) []*TableMetadata {
+	metas := make([]*TableMetadata, 0, len(schema))
+	for _, meta := range schema {
+		metas = append(metas, meta)
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].StructName < metas[j].StructName })
+	return metas
+}
+
 /*
 ================================================================================
 DEVELOPER MANUAL & DESIGN NOTES
@@ -495,7 +1250,18 @@ DEVELOPER MANUAL & DESIGN NOTES
 3. EDGE CASES HANDLED:
    - Spacing: "with:uid=id" vs "with: uid = id" are treated as equal.
    - Pointers: Supports *Struct and []*Struct (nested).
+   - Go Enums: `type Status string` plus a typed const group (string values or
+     iota) fills Constraints.Enum of Status columns, without an OpenAPI spec.
+     Integer enums keep their base type and label values by const name.
+   - Hidden Fields: `json:"-"` fields are left out of Columns entirely.
+   - Embedded Structs: Anonymous fields (e.g. an embedded Timestamps) are inlined
+     from any scanned file; g.Meta is skipped, unknown embeds are reported.
    - Implicit Keys: Handles `with:user_id` by defaulting source to `id`.
+   - Self References: `Children []*Category orm:"with:pid=id"` inside Category is
+     flagged IsSelfReference (tree); diagrams label it "self".
+   - Many-to-Many: two chained with segments name a join table, either as a key
+     qualifier (`with:user_tags.user_id=id, with:id=user_tags.tag_id`) or as a
+     `table:user_tags` segment; captured as ThroughTable/ThroughKeys.
    - Complex Tags: Correctly extracts 'with' even if 'table' or 'where' tags exist.
    - Parse Errors: Skips files with errors, logs warnings.
    - Cross-Platform: Normalizes file paths for Windows compatibility.
@@ -504,7 +1270,10 @@ DEVELOPER MANUAL & DESIGN NOTES
    You can convert the `SchemaMap` to JSON or pass it to `text/template`.
    - 1:1 Relations -> Generate a Detail Card or a Join query.
    - 1:N Relations -> Generate a Sub-Table or a Tabbed view.
-   - Mermaid visualization: Copy output to mermaid.live for architectural review.
+   - Mermaid visualization: Copy output to mermaid.live for architectural review
+     (-diagram er|class|both picks the ER diagram, the class diagram or both).
+   - Graphviz: -diagram-format dot emits a digraph instead; render it with
+     `dot -Tsvg schema.dot -o schema.svg`.
    - Use NormalizedName field to group related structs (do + api req) logically.
 ================================================================================
 */
@@ -517,7 +1286,7 @@ type openAPISpec struct {
 	Paths       map[string]openAPIPath `json:"paths"`
 	Components  openAPIComponents      `json:"components"`
 	Servers     []map[string]any       `json:"servers"`
-	Security    []map[string]any       `json:"security"`
+	Security    []map[string][]string  `json:"security"`
 	Tags        []map[string]any       `json:"tags"`
 	Extensions  map[string]any         `json:"-"`
 	Raw         map[string]any         `json:"-"`
@@ -525,7 +1294,8 @@ type openAPISpec struct {
 }
 
 type openAPIComponents struct {
-	Schemas map[string]*openAPISchema `json:"schemas"`
+	Schemas    map[string]*openAPISchema    `json:"schemas"`
+	Parameters map[string]*openAPIParameter `json:"parameters"`
 }
 
 type openAPIPath map[string]*openAPIOperation
@@ -534,9 +1304,24 @@ type openAPIOperation struct {
 	OperationID string                      `json:"operationId"`
 	Summary     string                      `json:"summary"`
 	Tags        []string                    `json:"tags"`
-	Parameters  []map[string]any            `json:"parameters"`
+	Parameters  []*openAPIParameter         `json:"parameters"`
 	RequestBody *openAPIRequestBody         `json:"requestBody"`
 	Responses   map[string]*openAPIResponse `json:"responses"`
+	// Nil inherits the global requirement; an empty list makes the operation public
+	Security []map[string][]string `json:"security"`
+}
+
+type openAPIParameter struct {
+	Ref      string         `json:"$ref"`
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+	// Swagger 2 puts the schema keywords on the parameter itself
+	Type   string         `json:"type"`
+	Format string         `json:"format"`
+	Items  *openAPISchema `json:"items"`
+	Enum   []any          `json:"enum"`
 }
 
 type openAPIRequestBody struct {
@@ -570,19 +1355,30 @@ type openAPISchema struct {
 	AllOf                []*openAPISchema          `json:"allOf"`
 	OneOf                []*openAPISchema          `json:"oneOf"`
 	AnyOf                []*openAPISchema          `json:"anyOf"`
+	Discriminator        *openAPIDiscriminator     `json:"discriminator"`
 	AdditionalProperties any                       `json:"additionalProperties"`
+	ReadOnly             bool                      `json:"readOnly"`
+	WriteOnly            bool                      `json:"writeOnly"`
+	Default              any                       `json:"default"`
+	Example              any                       `json:"example"`
+	XPrimaryKey          bool                      `json:"x-primary-key"`
+}
+
+type openAPIDiscriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping"`
 }
 
-func parseOpenAPIFile(path string) (SchemaMap, error) {
-	f, err := os.Open(path)
+func parseOpenAPIFile(path, token string) (SchemaMap, error) {
+	b, err := readOpenAPISource(path, token)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	b, err := io.ReadAll(f)
-	if err != nil {
-		return nil, err
+	if isYAMLSpec(path, b) {
+		if b, err = yamlToJSON(b); err != nil {
+			return nil, err
+		}
 	}
 
 	var spec openAPISpec
@@ -600,8 +1396,14 @@ func parseOpenAPIFile(path string) (SchemaMap, error) {
 
 	// 2) Operations associated to entities by request/response/tags/path heuristics
 	opsByNorm := make(map[string][]OperationInfo)
-	for p, methods := range spec.Paths {
-		for method, op := range methods {
+	// Query parameters of the collection GET; with several, the first path wins
+	filtersByNorm := make(map[string][]FilterParam)
+	filterPaths := make(map[string]string)
+	// Sorted, so operations keep the same order from run to run
+	for _, p := range slices.Sorted(maps.Keys(spec.Paths)) {
+		methods := spec.Paths[p]
+		for _, method := range slices.Sorted(maps.Keys(methods)) {
+			op := methods[method]
 			if op == nil {
 				continue
 			}
@@ -611,6 +1413,12 @@ func parseOpenAPIFile(path string) (SchemaMap, error) {
 				continue
 			}
 			opsByNorm[norm] = append(opsByNorm[norm], oi)
+			if oi.Method == "GET" && !strings.HasSuffix(p, "}") {
+				if prev, ok := filterPaths[norm]; !ok || p < prev {
+					filterPaths[norm] = p
+					filtersByNorm[norm] = openAPIFilterParams(&spec, op)
+				}
+			}
 		}
 	}
 
@@ -618,17 +1426,72 @@ func parseOpenAPIFile(path string) (SchemaMap, error) {
 		if ops, ok := opsByNorm[meta.NormalizedName]; ok {
 			meta.Operations = append(meta.Operations, ops...)
 		}
+		meta.FilterParams = filtersByNorm[meta.NormalizedName]
 	}
 
 	return out, nil
 }
 
+// openAPIFilterParams returns the query parameters of a collection GET in spec
+// order, resolving $ref parameters and enum schemas.
+func openAPIFilterParams(spec *openAPISpec, op *openAPIOperation) []FilterParam {
+	var params []FilterParam
+	for _, p := range op.Parameters {
+		if p != nil && p.Ref != "" {
+			p = spec.Components.Parameters[openAPIRefName(p.Ref)]
+		}
+		if p == nil || p.In != "query" || p.Name == "" {
+			continue
+		}
+		s := p.Schema
+		if s == nil {
+			s = &openAPISchema{Type: p.Type, Format: p.Format, Items: p.Items, Enum: p.Enum}
+		}
+		if s.Ref != "" {
+			if target := spec.Components.Schemas[openAPIRefName(s.Ref)]; target != nil {
+				s = target
+			}
+		}
+		typeName, _, _ := openAPITypeName(spec, s)
+		values := s.Enum
+		if s.Type == "array" && s.Items != nil {
+			values = s.Items.Enum
+		}
+		var enum []string
+		for _, v := range values {
+			enum = append(enum, fmt.Sprint(v))
+		}
+		params = append(params, FilterParam{
+			Name:     p.Name,
+			Type:     typeName,
+			Enum:     enum,
+			Required: p.Required,
+		})
+	}
+	return params
+}
+
 func openAPISchemaToTableMetadata(spec *openAPISpec, schemaName string, schema *openAPISchema) *TableMetadata {
+	visited := map[string]bool{schemaName: true}
+	return &TableMetadata{
+		StructName:     schemaName,
+		NormalizedName: normalizeEntityName(schemaName),
+		Source:         "openapi",
+		Columns:        openAPIColumns(spec, schema, visited),
+		Relations:      []*RelationNode{},
+		Operations:     []OperationInfo{},
+	}
+}
+
+// openAPIColumns flattens an object schema into columns, sorted by property name.
+// A property referencing another object schema (directly or as array items) without
+// FK naming is an embedded object: its own columns are kept as NestedColumns. visited holds the schemas on
+// the current path, so self-referencing schemas stop instead of recursing forever.
+func openAPIColumns(spec *openAPISpec, schema *openAPISchema, visited map[string]bool) []ColumnInfo {
 	props := make(map[string]*openAPISchema)
 	required := make(map[string]bool)
 
-	visited := make(map[string]bool)
-	collectOpenAPIObject(spec, schema, visited, props, required)
+	collectOpenAPIObject(spec, schema, copyVisited(visited), props, required)
 
 	cols := make([]ColumnInfo, 0, len(props))
 	keys := make([]string, 0, len(props))
@@ -647,27 +1510,139 @@ func openAPISchemaToTableMetadata(spec *openAPISpec, schemaName string, schema *
 			}
 			c.Required = true
 		}
+		// Surface the key extension as the same "pk" hint a Go ad tag would carry
+		additional := ""
+		if ps.XPrimaryKey {
+			additional = "pk"
+		}
+
+		var nested []ColumnInfo
+		if refName != "" && !visited[refName] && !isForeignKeyName(propName) {
+			if target := spec.Components.Schemas[refName]; target != nil {
+				inner := copyVisited(visited)
+				inner[refName] = true
+				nested = openAPIColumns(spec, target, inner)
+			}
+		}
+		discriminator, variants := openAPIVariants(spec, ps, visited)
+		if len(variants) > 0 && typeName == "Unknown" {
+			typeName = "object"
+		}
 
 		cols = append(cols, ColumnInfo{
-			Name:        propName,
-			JSONName:    propName,
-			Type:        typeName,
-			Description: ps.Description,
-			Constraints: c,
-			Ref:         refName,
-			IsArray:     isArray,
-			Source:      "openapi",
+			Name:          propName,
+			JSONName:      propName,
+			Type:          typeName,
+			Description:   ps.Description,
+			Example:       exampleString(ps.Example),
+			Additional:    additional,
+			Constraints:   c,
+			Ref:           refName,
+			IsArray:       isArray,
+			Source:        "openapi",
+			NestedColumns: nested,
+			Discriminator: discriminator,
+			Variants:      variants,
 		})
 	}
+	return cols
+}
 
-	return &TableMetadata{
-		StructName:     schemaName,
-		NormalizedName: normalizeEntityName(schemaName),
-		Source:         "openapi",
-		Columns:        cols,
-		Relations:      []*RelationNode{},
-		Operations:     []OperationInfo{},
+// openAPIVariants returns the discriminator and branches of a oneOf/anyOf schema,
+// looked up through a $ref. Only component branches are kept, since inline ones
+// have no name to select them by. Without a discriminator nothing is returned and
+// the first branch stands for the schema (see collectOpenAPIObject).
+func openAPIVariants(spec *openAPISpec, s *openAPISchema, visited map[string]bool) (string, []VariantInfo) {
+	if s != nil && s.Ref != "" {
+		name := openAPIRefName(s.Ref)
+		if visited[name] {
+			return "", nil
+		}
+		s = spec.Components.Schemas[name]
+	}
+	if s == nil || s.Discriminator == nil || s.Discriminator.PropertyName == "" {
+		return "", nil
+	}
+	branches := s.OneOf
+	if len(branches) == 0 {
+		branches = s.AnyOf
+	}
+
+	// Mapping is value → $ref; sorted so a branch mapped twice gets a stable value
+	values := make(map[string]string, len(s.Discriminator.Mapping))
+	keys := make([]string, 0, len(s.Discriminator.Mapping))
+	for k := range s.Discriminator.Mapping {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ref := s.Discriminator.Mapping[k]
+		name := openAPIRefName(ref)
+		if name == "" {
+			name = ref // bare schema name
+		}
+		if _, ok := values[name]; !ok {
+			values[name] = k
+		}
+	}
+
+	var variants []VariantInfo
+	for _, b := range branches {
+		if b == nil || b.Ref == "" {
+			continue
+		}
+		name := openAPIRefName(b.Ref)
+		target := spec.Components.Schemas[name]
+		if name == "" || target == nil || visited[name] {
+			continue
+		}
+		value := values[name]
+		if value == "" {
+			value = name
+		}
+		inner := copyVisited(visited)
+		inner[name] = true
+		variants = append(variants, VariantInfo{
+			Name:    name,
+			Value:   value,
+			Columns: openAPIColumns(spec, target, inner),
+		})
+	}
+	if len(variants) == 0 {
+		return "", nil
+	}
+	return s.Discriminator.PropertyName, variants
+}
+
+func copyVisited(visited map[string]bool) map[string]bool {
+	out := make(map[string]bool, len(visited)+1)
+	for k, v := range visited {
+		out[k] = v
+	}
+	return out
+}
+
+// isForeignKeyName reports whether a property is named like a key into another
+// entity (user_id, userId, userID) rather than an embedded object.
+func isForeignKeyName(name string) bool {
+	lower := strings.ToLower(name)
+	return lower != "id" && strings.HasSuffix(lower, "id") &&
+		(strings.HasSuffix(lower, "_id") || strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID"))
+}
+
+// exampleString renders an OpenAPI example as text: strings as-is, anything else as JSON.
+func exampleString(v any) string {
+	switch ex := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return ex
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
 	}
+	return string(b)
 }
 
 func collectOpenAPIObject(spec *openAPISpec, s *openAPISchema, visited map[string]bool, props map[string]*openAPISchema, required map[string]bool) {
@@ -698,6 +1673,7 @@ func collectOpenAPIObject(spec *openAPISpec, s *openAPISchema, visited map[strin
 
 	// oneOf/anyOf are preserved as a first-class schema feature in OpenAPI;
 	// object property extraction selects a deterministic branch for metadata purposes.
+	// Discriminated properties also keep every branch, see openAPIVariants.
 	if len(s.OneOf) > 0 {
 		collectOpenAPIObject(spec, s.OneOf[0], visited, props, required)
 	}
@@ -774,6 +1750,9 @@ func openAPIConstraintsForSchema(s *openAPISchema) *FieldConstraints {
 		Pattern:   s.Pattern,
 		Format:    s.Format,
 		Enum:      enumStrings,
+		ReadOnly:  s.ReadOnly,
+		WriteOnly: s.WriteOnly,
+		Default:   s.Default,
 	}
 
 	if constraintsEmpty(c) {
@@ -786,7 +1765,7 @@ func constraintsEmpty(c *FieldConstraints) bool {
 	if c == nil {
 		return true
 	}
-	if c.Required || c.Nullable {
+	if c.Required || c.Nullable || c.ReadOnly || c.WriteOnly {
 		return false
 	}
 	if c.MinLength != nil || c.MaxLength != nil || c.Minimum != nil || c.Maximum != nil {
@@ -795,7 +1774,7 @@ func constraintsEmpty(c *FieldConstraints) bool {
 	if c.Pattern != "" || c.Format != "" {
 		return false
 	}
-	if len(c.Enum) > 0 {
+	if len(c.Enum) > 0 || c.Default != nil {
 		return false
 	}
 	return true
@@ -816,6 +1795,10 @@ func openAPIRefName(ref string) string {
 func openAPIOperationInfo(spec *openAPISpec, path, method string, op *openAPIOperation) OperationInfo {
 	reqSchema := pickOpenAPISchemaRefName(op.RequestBody)
 	respSchema := pickOpenAPIResponseSchemaRefName(op.Responses)
+	security := op.Security
+	if security == nil {
+		security = spec.Security
+	}
 
 	return OperationInfo{
 		Method:         method,
@@ -826,7 +1809,22 @@ func openAPIOperationInfo(spec *openAPISpec, path, method string, op *openAPIOpe
 		RequestSchema:  reqSchema,
 		ResponseSchema: respSchema,
 		Source:         "openapi",
+		RequiresAuth:   requiresAuth(security),
+	}
+}
+
+// requiresAuth reports whether a security requirement list demands credentials.
+// Alternatives are OR-ed, so an empty requirement ({}) among them makes auth optional.
+func requiresAuth(security []map[string][]string) bool {
+	if len(security) == 0 {
+		return false
+	}
+	for _, req := range security {
+		if len(req) == 0 {
+			return false
+		}
 	}
+	return true
 }
 
 func pickOpenAPISchemaRefName(rb *openAPIRequestBody) string {
@@ -937,6 +1935,579 @@ func entityFromPathHeuristic(p string) string {
 	return strings.ToUpper(last[:1]) + last[1:]
 }
 
+// openAPIFetchTimeout bounds the download of a spec given as a URL.
+const openAPIFetchTimeout = 30 * time.Second
+
+// readOpenAPISource reads a spec from a file, or downloads it from an http(s) URL.
+// Downloads are cached per user (see openAPICachePath); when one fails, the cached
+// copy is used.
+func readOpenAPISource(path, token string) ([]byte, error) {
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		return os.ReadFile(path)
+	}
+
+	cache, cerr := openAPICachePath(path, token)
+	b, err := fetchOpenAPI(path, token)
+	if err == nil {
+		if cerr == nil {
+			cerr = writePrivateFile(cache, b)
+		}
+		if cerr != nil {
+			fmt.Printf("⚠️ Could not cache %s: %v\n", path, cerr)
+		}
+		return b, nil
+	}
+	if cerr != nil {
+		return nil, err
+	}
+	info, serr := os.Stat(cache)
+	if serr != nil {
+		return nil, err
+	}
+	cached, rerr := os.ReadFile(cache)
+	if rerr != nil {
+		return nil, err
+	}
+	fmt.Printf("⚠️ Fetching %s failed (%v); using the copy cached %s\n", path, err, info.ModTime().Format(time.DateTime))
+	return cached, nil
+}
+
+// openAPICachePath returns the cache file for a spec URL, keyed by the URL and the
+// token it was fetched with, so one token's spec is never served for another. The
+// directory is parse_schema under the user cache dir (the temp dir if there is none),
+// created 0700 and refused if it is a symlink or open to other users.
+func openAPICachePath(url, token string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	dir := filepath.Join(base, "parse_schema")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() || info.Mode().Perm()&0o077 != 0 {
+		return "", fmt.Errorf("cache dir %s must be a directory with mode 0700", dir)
+	}
+	key := sha256.Sum256([]byte(url + "\x00" + token))
+	return filepath.Join(dir, fmt.Sprintf("openapi-%x", key)), nil
+}
+
+// writePrivateFile writes b to path with mode 0600, through a temp file and a
+// rename so an existing file's mode is not kept and readers never see a partial spec.
+func writePrivateFile(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func fetchOpenAPI(url, token string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml;q=0.9, */*;q=0.8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := &http.Client{Timeout: openAPIFetchTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ---- OpenAPI YAML ---------------------------------------------------------------
+
+// isYAMLSpec reports whether a spec file is YAML: by extension, else by content,
+// since a JSON document starts with "{".
+func isYAMLSpec(path string, b []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+	trimmed := strings.TrimSpace(string(b))
+	return trimmed != "" && trimmed[0] != '{'
+}
+
+// yamlToJSON converts a YAML OpenAPI document to JSON, so both decode into the
+// same structs. The tool stays dependency-free, so this is not a general YAML
+// decoder; it accepts the subset spec writers and exporters produce:
+//
+//   - block mappings and sequences, including "- key: value" items, indented with spaces
+//   - plain, 'single' and "double" quoted scalars on one line (null, booleans and
+//     numbers resolve as in YAML 1.2 core; everything else is a string)
+//   - flow collections on one line, e.g. [a, b] or {type: string}
+//   - | and > block scalars, with the - (strip) chomping indicator
+//   - # comments and a leading "---"
+//
+// Anything else (anchors, aliases, tags, merge keys, multi-line flow collections or
+// plain scalars, multi-document streams) is rejected with its line number rather
+// than misread; convert such specs to JSON first.
+func yamlToJSON(b []byte) ([]byte, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(raw, " "), "\t") {
+			return nil, fmt.Errorf("YAML line %d: tabs are not allowed for indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, raw: strings.TrimRight(raw, " \t")})
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.cur().text() == "---" {
+		p.pos++
+		p.skipBlank()
+	}
+	if p.pos >= len(p.lines) {
+		return nil, fmt.Errorf("empty YAML document")
+	}
+	v, err := p.parseBlock(p.cur().indent())
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected %q (multi-document streams are not supported)", p.cur().text())
+	}
+	return json.Marshal(v)
+}
+
+type yamlLine struct {
+	num int
+	raw string
+}
+
+func (l yamlLine) indent() int { return len(l.raw) - len(strings.TrimLeft(l.raw, " ")) }
+
+// text is the line content without indentation and trailing comment.
+func (l yamlLine) text() string { return strings.TrimSpace(stripYAMLComment(l.raw)) }
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) cur() yamlLine { return p.lines[p.pos] }
+
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text() == "" {
+		p.pos++
+	}
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	num := len(p.lines)
+	if p.pos < len(p.lines) {
+		num = p.lines[p.pos].num
+	}
+	return fmt.Errorf("YAML line %d: %s", num, fmt.Sprintf(format, args...))
+}
+
+// parseBlock parses the mapping or sequence starting at the current line.
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isYAMLSeqItem(p.cur().text()) {
+		return p.parseSeq(indent)
+	}
+	return p.parseMap(indent)
+}
+
+// parseNested parses the block value of a key or "-" whose value starts on the
+// next line. A mapping's sequence may sit at the key's own indentation.
+func (p *yamlParser) parseNested(indent int, seqAtIndent bool) (any, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	l := p.cur()
+	if l.indent() > indent || (seqAtIndent && l.indent() == indent && isYAMLSeqItem(l.text())) {
+		return p.parseBlock(l.indent())
+	}
+	return nil, nil
+}
+
+func (p *yamlParser) parseSeq(indent int) (any, error) {
+	out := []any{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return out, nil
+		}
+		l := p.cur()
+		if l.indent() > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if l.indent() < indent || !isYAMLSeqItem(l.text()) {
+			return out, nil
+		}
+		rest := strings.TrimSpace(l.text()[1:])
+		var item any
+		var err error
+		switch {
+		case rest == "":
+			p.pos++
+			item, err = p.parseNested(indent, false)
+		case isYAMLSeqItem(rest) || isYAMLMapEntry(rest):
+			// "- key: value": blank out the dash so the entry opens a nested block
+			p.lines[p.pos].raw = l.raw[:indent] + " " + l.raw[indent+1:]
+			item, err = p.parseBlock(p.cur().indent())
+		default:
+			item, err = p.parseInline(rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+}
+
+func (p *yamlParser) parseMap(indent int) (any, error) {
+	out := map[string]any{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return out, nil
+		}
+		l := p.cur()
+		t := l.text()
+		if l.indent() < indent || t == "---" || t == "..." {
+			return out, nil // Document markers are reported by yamlToJSON
+		}
+		if l.indent() > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if isYAMLSeqItem(t) {
+			return out, nil // The sequence value of the enclosing key
+		}
+		key, rest, ok := splitYAMLKey(t)
+		if !ok {
+			return nil, p.errorf("expected \"key: value\", got %q", t)
+		}
+		if key == "<<" {
+			return nil, p.errorf("merge keys (<<) are not supported")
+		}
+		var v any
+		var err error
+		if rest == "" {
+			p.pos++
+			v, err = p.parseNested(indent, true)
+		} else {
+			v, err = p.parseInline(rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+}
+
+// parseInline parses a value starting on the current line after "key:" or "-".
+// Only block scalars continue on the following lines.
+func (p *yamlParser) parseInline(rest string, indent int) (any, error) {
+	var v any
+	var err error
+	switch rest[0] {
+	case '&', '*':
+		return nil, p.errorf("anchors and aliases are not supported")
+	case '!':
+		return nil, p.errorf("tags are not supported")
+	case '|', '>':
+		return p.parseBlockScalar(rest, indent)
+	case '[', '{':
+		f := &yamlFlow{s: rest}
+		if v, err = f.value(); err == nil {
+			f.skipSpace()
+			if f.i < len(f.s) {
+				err = fmt.Errorf("unexpected %q after flow collection", f.s[f.i:])
+			}
+		}
+	default:
+		v, err = yamlScalar(rest)
+	}
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	p.pos++
+	if next := p.pos; next < len(p.lines) && p.lines[next].text() != "" && p.lines[next].indent() > indent &&
+		!isYAMLSeqItem(p.lines[next].text()) && !isYAMLMapEntry(p.lines[next].text()) {
+		p.pos = next
+		return nil, p.errorf("values continued on the next line are not supported")
+	}
+	return v, nil
+}
+
+// parseBlockScalar reads a literal (|) or folded (>) block: lines indented past
+// the key, joined with newlines (|) or spaces (>), with one final newline unless
+// the header is |- or >-.
+func (p *yamlParser) parseBlockScalar(header string, indent int) (any, error) {
+	if header != "|" && header != ">" && header != "|-" && header != ">-" {
+		return nil, p.errorf("block scalar header %q is not supported (use |, >, |- or >-)", header)
+	}
+	p.pos++
+	var lines []string
+	blockIndent := -1
+	for p.pos < len(p.lines) {
+		raw := p.lines[p.pos].raw
+		if strings.TrimSpace(raw) == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+		ind := len(raw) - len(strings.TrimLeft(raw, " "))
+		if ind <= indent || (blockIndent >= 0 && ind < blockIndent) {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = ind
+		}
+		lines = append(lines, raw[blockIndent:])
+		p.pos++
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	folded := header[0] == '>'
+	var sb strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			switch prev := lines[i-1]; {
+			case folded && l != "" && prev != "":
+				sb.WriteString(" ")
+			case folded && l != "":
+				// The blank line before already broke the fold
+			default:
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString(l)
+	}
+	text := sb.String()
+	if text != "" && !strings.HasSuffix(header, "-") {
+		text += "\n"
+	}
+	return text, nil
+}
+
+func isYAMLSeqItem(t string) bool { return t == "-" || strings.HasPrefix(t, "- ") }
+
+func isYAMLMapEntry(t string) bool {
+	_, _, ok := splitYAMLKey(t)
+	return ok
+}
+
+// splitYAMLKey splits "key: value" (or "key:") at the first ": " outside quotes.
+func splitYAMLKey(t string) (key, rest string, ok bool) {
+	if t == "" || t[0] == '[' || t[0] == '{' {
+		return "", "", false
+	}
+	if t[0] == '"' || t[0] == '\'' {
+		end := closingQuote(t)
+		if end < 0 || end+1 >= len(t) || t[end+1] != ':' || (end+2 < len(t) && t[end+2] != ' ') {
+			return "", "", false
+		}
+		k, err := yamlScalar(t[:end+1])
+		if err != nil {
+			return "", "", false
+		}
+		return fmt.Sprint(k), strings.TrimSpace(t[end+2:]), true
+	}
+	if strings.HasSuffix(t, ":") && !strings.Contains(t, ": ") {
+		return strings.TrimSpace(t[:len(t)-1]), "", true
+	}
+	i := strings.Index(t, ": ")
+	if i < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(t[:i]), strings.TrimSpace(t[i+2:]), true
+}
+
+// closingQuote returns the index of the quote closing the one at s[0], or -1.
+func closingQuote(s string) int {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q && q == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++ // '' is an escaped quote
+		case s[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment drops a "#" comment that starts a line or follows a space,
+// outside quotes. Quotes only open at the start of a token, so "don't" is plain.
+func stripYAMLComment(s string) string {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t[{,:-", s[i-1]) >= 0):
+			if end := closingQuote(s[i:]); end > 0 {
+				i += end
+			}
+		}
+	}
+	return s
+}
+
+var yamlNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+// yamlScalar resolves a plain or quoted scalar to nil, a bool, a number or a string.
+func yamlScalar(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		if closingQuote(s) != len(s)-1 {
+			return nil, fmt.Errorf("unterminated string %s", s)
+		}
+		if s[0] == '\'' {
+			return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+		}
+		var out string
+		if err := json.Unmarshal([]byte(s), &out); err == nil {
+			return out, nil
+		}
+		return strconv.Unquote(s)
+	}
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if yamlNumber.MatchString(s) {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+	}
+	return s, nil
+}
+
+// yamlFlow parses a one-line flow collection such as [a, b] or {type: string, enum: [x]}.
+type yamlFlow struct {
+	s string
+	i int
+}
+
+func (f *yamlFlow) skipSpace() {
+	for f.i < len(f.s) && (f.s[f.i] == ' ' || f.s[f.i] == '\t') {
+		f.i++
+	}
+}
+
+func (f *yamlFlow) value() (any, error) {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return nil, fmt.Errorf("unclosed flow collection (multi-line flow collections are not supported)")
+	}
+	switch f.s[f.i] {
+	case '[':
+		f.i++
+		out := []any{}
+		for {
+			f.skipSpace()
+			if f.i < len(f.s) && f.s[f.i] == ']' {
+				f.i++
+				return out, nil
+			}
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.i++
+		out := map[string]any{}
+		for {
+			f.skipSpace()
+			if f.i < len(f.s) && f.s[f.i] == '}' {
+				f.i++
+				return out, nil
+			}
+			k, err := f.scalar(":,}")
+			if err != nil {
+				return nil, err
+			}
+			f.skipSpace()
+			var v any
+			if f.i < len(f.s) && f.s[f.i] == ':' {
+				f.i++
+				if v, err = f.value(); err != nil {
+					return nil, err
+				}
+			}
+			out[fmt.Sprint(k)] = v
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.scalar(",]}")
+}
+
+// separator consumes the "," between items, leaving the closing bracket in place.
+func (f *yamlFlow) separator(closing byte) error {
+	f.skipSpace()
+	switch {
+	case f.i < len(f.s) && f.s[f.i] == ',':
+		f.i++
+		return nil
+	case f.i < len(f.s) && f.s[f.i] == closing:
+		return nil
+	case f.i >= len(f.s):
+		return fmt.Errorf("unclosed flow collection (multi-line flow collections are not supported)")
+	}
+	return fmt.Errorf("expected ',' or '%c' in flow collection", closing)
+}
+
+// scalar reads a quoted scalar, or a plain one up to any of the stop bytes.
+func (f *yamlFlow) scalar(stops string) (any, error) {
+	f.skipSpace()
+	start := f.i
+	if f.i < len(f.s) && (f.s[f.i] == '"' || f.s[f.i] == '\'') {
+		end := closingQuote(f.s[f.i:])
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string in flow collection")
+		}
+		f.i += end + 1
+		return yamlScalar(f.s[start:f.i])
+	}
+	for f.i < len(f.s) && strings.IndexByte(stops, f.s[f.i]) < 0 {
+		f.i++
+	}
+	return yamlScalar(f.s[start:f.i])
+}
+
 // ---- Consolidation ------------------------------------------------------------
 
 func consolidateByNormalizedName(schema SchemaMap) ConsolidatedSchema {
@@ -990,6 +2561,9 @@ func cloneTableMetadata(in *TableMetadata) *TableMetadata {
 	if len(in.Operations) > 0 {
 		out.Operations = append([]OperationInfo(nil), in.Operations...)
 	}
+	if len(in.FilterParams) > 0 {
+		out.FilterParams = append([]FilterParam(nil), in.FilterParams...)
+	}
 	return out
 }
 
@@ -1003,6 +2577,9 @@ func mergeTableMetadata(dst, src *TableMetadata) {
 	mergeColumns(&dst.Columns, src.Columns)
 	mergeRelations(&dst.Relations, src.Relations)
 	mergeOperations(&dst.Operations, src.Operations)
+	if len(dst.FilterParams) == 0 {
+		dst.FilterParams = src.FilterParams
+	}
 
 	// Prefer the most specific struct name when OpenAPI provides canonical schema names.
 	if dst.StructName == "" || (dst.Source == "merged" && src.Source == "openapi") {
@@ -1055,15 +2632,26 @@ func mergeColumn(a, b ColumnInfo) ColumnInfo {
 	if out.Description == "" {
 		out.Description = b.Description
 	}
+	if out.Example == "" {
+		out.Example = b.Example
+	}
 	if out.Validation == "" {
 		out.Validation = b.Validation
 	}
 	if out.Additional == "" {
 		out.Additional = b.Additional
+	} else if b.Additional != "" && b.Additional != out.Additional {
+		out.Additional += "," + b.Additional
 	}
 	if out.Ref == "" {
 		out.Ref = b.Ref
 	}
+	if len(out.NestedColumns) == 0 {
+		out.NestedColumns = b.NestedColumns
+	}
+	if len(out.Variants) == 0 {
+		out.Discriminator, out.Variants = b.Discriminator, b.Variants
+	}
 	out.IsArray = out.IsArray || b.IsArray
 
 	out.Constraints = mergeConstraints(out.Constraints, b.Constraints)
@@ -1091,6 +2679,8 @@ func mergeConstraints(a, b *FieldConstraints) *FieldConstraints {
 
 	out.Required = out.Required || b.Required
 	out.Nullable = out.Nullable || b.Nullable
+	out.ReadOnly = out.ReadOnly || b.ReadOnly
+	out.WriteOnly = out.WriteOnly || b.WriteOnly
 
 	out.MinLength = pickIntPtrMax(out.MinLength, b.MinLength)
 	out.MaxLength = pickIntPtrMin(out.MaxLength, b.MaxLength)
@@ -1106,6 +2696,10 @@ func mergeConstraints(a, b *FieldConstraints) *FieldConstraints {
 	}
 	if len(out.Enum) == 0 && len(b.Enum) > 0 {
 		out.Enum = append([]string(nil), b.Enum...)
+		out.EnumLabels = append([]string(nil), b.EnumLabels...)
+	}
+	if out.Default == nil {
+		out.Default = b.Default
 	}
 
 	if constraintsEmpty(&out) {
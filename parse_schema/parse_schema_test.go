@@ -0,0 +1,778 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writeTestFile writes content to name under dir, creating parent directories.
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// parseTestModels writes files (slash path -> Go source) to a temp project and
+// parses them the way main's walk does: model/do and api files as models, anything
+// else for enums only. Sources quote struct tags with ' instead of backquotes,
+// which raw string literals cannot hold.
+func parseTestModels(t *testing.T, files map[string]string) SchemaMap {
+	t.Helper()
+	dir := t.TempDir()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schema, enums := make(SchemaMap), newGoEnums()
+	for _, name := range names {
+		path := writeTestFile(t, dir, name, strings.ReplaceAll(files[name], "'", "`"))
+		if strings.Contains(name, "/model/do") || strings.Contains(name, "/api") {
+			parseFile(path, schema, enums)
+		} else {
+			parseEnumFile(path, enums)
+		}
+	}
+	inlineEmbeds(schema)
+	applyGoEnums(schema, enums)
+	return schema
+}
+
+// findColumn returns the column of meta with the given JSON (else Go) name, or nil.
+func findColumn(meta *TableMetadata, name string) *ColumnInfo {
+	for i := range meta.Columns {
+		if columnJSONName(meta.Columns[i]) == name {
+			return &meta.Columns[i]
+		}
+	}
+	return nil
+}
+
+func TestOpenAPIDefaults(t *testing.T) {
+	path := writeTestFile(t, t.TempDir(), "openapi.json", `{
+  "openapi": "3.0.0",
+  "paths": {},
+  "components": {"schemas": {"Article": {
+    "type": "object",
+    "properties": {
+      "title": {"type": "string", "default": "Untitled"},
+      "priority": {"type": "integer", "default": 3},
+      "published": {"type": "boolean", "default": false},
+      "slug": {"type": "string"}
+    }
+  }}}
+}`)
+	schema, err := parseOpenAPIFile(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := schema["Article"]
+	if meta == nil {
+		t.Fatal("Article schema missing")
+	}
+	tests := []struct {
+		name string
+		want any
+	}{
+		{"title", "Untitled"},
+		{"priority", 3.0},
+		{"published", false},
+		{"slug", nil},
+	}
+	for _, tt := range tests {
+		col := findColumn(meta, tt.name)
+		if col == nil {
+			t.Errorf("%s: no column", tt.name)
+			continue
+		}
+		var got any
+		if col.Constraints != nil {
+			got = col.Constraints.Default
+		}
+		if got != tt.want {
+			t.Errorf("%s: Default = %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOpenAPIYAMLMatchesJSON(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := writeTestFile(t, dir, "openapi.yaml", `openapi: 3.0.0 # comment
+info:
+  title: "Shop: API"
+  version: '1.0'
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      tags: [product]
+      parameters:
+        - name: status
+          in: query
+          schema: {type: string, enum: [draft, live]}
+        - name: page
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+    post:
+      operationId: createProduct
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Product"
+      responses:
+        '201': {description: Created}
+components:
+  schemas:
+    Product:
+      type: object
+      description: |
+        A product in the catalogue.
+        Shown on the shop page.
+      required:
+      - name
+      - price
+      properties:
+        id:
+          type: integer
+          x-primary-key: true
+        name:
+          type: string
+          maxLength: 120
+          description: >-
+            Display name,
+            unique per shop
+        price:
+          type: number
+          minimum: 0.5
+        status:
+          type: string
+          enum: ["draft", 'live', sold-out]
+          default: draft
+        note:
+          type: string
+          nullable: true
+          example: ~
+        categoryId:
+          type: integer
+          nullable: true
+        category:
+          $ref: '#/components/schemas/Category'
+        tags:
+          type: array
+          items: {type: string}
+    Category:
+      type: object
+      properties:
+        id: {type: integer}
+        title: {type: string, default: "It's new"}
+`)
+	jsonPath := writeTestFile(t, dir, "openapi.json", `{
+  "openapi": "3.0.0",
+  "info": {"title": "Shop: API", "version": "1.0"},
+  "paths": {
+    "/products": {
+      "get": {
+        "operationId": "listProducts",
+        "tags": ["product"],
+        "parameters": [
+          {"name": "status", "in": "query", "schema": {"type": "string", "enum": ["draft", "live"]}},
+          {"name": "page", "in": "query", "schema": {"type": "integer"}}
+        ],
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {
+          "type": "array", "items": {"$ref": "#/components/schemas/Product"}
+        }}}}}
+      },
+      "post": {
+        "operationId": "createProduct",
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Product"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "components": {"schemas": {
+    "Product": {
+      "type": "object",
+      "description": "A product in the catalogue.\nShown on the shop page.\n",
+      "required": ["name", "price"],
+      "properties": {
+        "id": {"type": "integer", "x-primary-key": true},
+        "name": {"type": "string", "maxLength": 120, "description": "Display name, unique per shop"},
+        "price": {"type": "number", "minimum": 0.5},
+        "status": {"type": "string", "enum": ["draft", "live", "sold-out"], "default": "draft"},
+        "note": {"type": "string", "nullable": true, "example": null},
+        "categoryId": {"type": "integer", "nullable": true},
+        "category": {"$ref": "#/components/schemas/Category"},
+        "tags": {"type": "array", "items": {"type": "string"}}
+      }
+    },
+    "Category": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "integer"},
+        "title": {"type": "string", "default": "It's new"}
+      }
+    }
+  }}
+}`)
+	fromYAML, err := parseOpenAPIFile(yamlPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := parseOpenAPIFile(jsonPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fromJSON) == 0 || len(fromJSON["Product"].Operations) == 0 {
+		t.Fatalf("JSON spec parsed to %d schemas; the fixture should produce entities and operations", len(fromJSON))
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		y, _ := json.MarshalIndent(fromYAML, "", "  ")
+		j, _ := json.MarshalIndent(fromJSON, "", "  ")
+		t.Errorf("YAML and JSON specs parse differently\nYAML: %s\nJSON: %s", y, j)
+	}
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"scalars", "a: 1\nb: 1.5\nc: true\nd: ~\ne: 'it''s'\nf: \"x\\ty\"\ng: plain # comment\n",
+			`{"a":1,"b":1.5,"c":true,"d":null,"e":"it's","f":"x\ty","g":"plain"}`},
+		{"sequence at key indent", "tags:\n- a\n- b\n", `{"tags":["a","b"]}`},
+		{"compact mapping items", "p:\n  - name: id\n    in: path\n  - name: q\n", `{"p":[{"in":"path","name":"id"},{"name":"q"}]}`},
+		{"flow", "s: {type: string, enum: [a, 'b, c']}\n", `{"s":{"enum":["a","b, c"],"type":"string"}}`},
+		{"literal block", "d: |\n  one\n  two\nn: 1\n", `{"d":"one\ntwo\n","n":1}`},
+		{"folded block", "d: >-\n  one\n  two\n\n  three\n", `{"d":"one two\nthree"}`},
+		{"document start", "---\na: 1\n", `{"a":1}`},
+	}
+	for _, tt := range tests {
+		got, err := yamlToJSON([]byte(tt.in))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestYAMLToJSONRejectsUnsupported(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"anchor", "a: &x 1\n", "line 1: anchors"},
+		{"alias", "a: 1\nb: *x\n", "line 2: anchors"},
+		{"tag", "a: !!str 1\n", "line 1: tags"},
+		{"merge key", "a:\n  <<: {b: 1}\n", "line 2: merge keys"},
+		{"multi-document", "a: 1\n---\nb: 2\n", "line 2: unexpected \"---\""},
+		{"multi-line flow", "a: [x,\n  y]\n", "line 1: unclosed flow"},
+		{"multi-line plain scalar", "a: one\n  two\n", "line 2: values continued"},
+		{"keep chomping", "a: |+\n  x\n", "line 1: block scalar header"},
+		{"tab indentation", "a:\n\tb: 1\n", "line 2: tabs"},
+	}
+	for _, tt := range tests {
+		_, err := yamlToJSON([]byte(tt.in))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestDetectPrimaryKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   string
+		cols     []ColumnInfo
+		wantPK   string
+		wantAuto bool
+	}{
+		{"uid keyed", "Member", []ColumnInfo{
+			{Name: "Name", JSONName: "name", Type: "string"},
+			{Name: "Uid", JSONName: "uid", Type: "string"},
+		}, "uid", false},
+		{"entity id", "Person", []ColumnInfo{
+			{Name: "Name", JSONName: "name", Type: "string"},
+			{Name: "PersonId", JSONName: "personId", Type: "uint"},
+		}, "personId", true},
+		{"digit before Id", "V2", []ColumnInfo{
+			{Name: "Code", JSONName: "code", Type: "string"},
+			{Name: "V2Id", JSONName: "v2Id", Type: "string"},
+		}, "code", false},
+		{"pk hint", "Member", []ColumnInfo{
+			{Name: "Id", JSONName: "id", Type: "int"},
+			{Name: "Code", JSONName: "code", Type: "string", Additional: "pk"},
+		}, "code", false},
+		{"natural integer key", "Country", []ColumnInfo{
+			{Name: "NumericCode", JSONName: "numericCode", Type: "int", Additional: "pk"},
+		}, "numericCode", false},
+		{"readOnly key", "Token", []ColumnInfo{
+			{Name: "Uuid", JSONName: "uuid", Type: "string", Constraints: &FieldConstraints{ReadOnly: true}},
+		}, "uuid", true},
+	}
+	for _, tt := range tests {
+		pk, auto := detectPrimaryKey(&TableMetadata{NormalizedName: tt.entity, Columns: tt.cols})
+		if pk != tt.wantPK || auto != tt.wantAuto {
+			t.Errorf("%s: detectPrimaryKey = %q, %v; want %q, %v", tt.name, pk, auto, tt.wantPK, tt.wantAuto)
+		}
+	}
+}
+
+// TestPrimaryKeyMatchesGenQuasar guards the copy of gen_quasar's primary key
+// detection: both tools must pick the same key for diagrams and the UI.
+func TestPrimaryKeyMatchesGenQuasar(t *testing.T) {
+	funcs := func(path string) map[string]string {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := map[string]string{}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				var sb strings.Builder
+				if err := printer.Fprint(&sb, fset, fn); err != nil {
+					t.Fatal(err)
+				}
+				out[fn.Name.Name] = sb.String()
+			}
+		}
+		return out
+	}
+	ours, theirs := funcs("parse_schema.go"), funcs(filepath.Join("..", "gen_quasar", "gen_quasar.go"))
+	for _, name := range []string{"detectPrimaryKey", "columnJSONName", "hasHint", "splitWords", "toSnake"} {
+		if ours[name] != theirs[name] {
+			t.Errorf("%s differs from gen_quasar's copy", name)
+		}
+	}
+}
+
+func TestColumnKeys(t *testing.T) {
+	tests := []struct {
+		col  ColumnInfo
+		pk   string
+		want string
+	}{
+		{ColumnInfo{Name: "PersonId", JSONName: "person_id", Type: "uint"}, "person_id", "PK"},
+		{ColumnInfo{Name: "Id", JSONName: "id", Type: "uint"}, "id", "PK"},
+		{ColumnInfo{Name: "UserId", JSONName: "user_id", Type: "uint"}, "id", "FK"},
+		{ColumnInfo{Name: "owner_id", JSONName: "owner_id", Type: "int", Ref: "User"}, "owner_id", "PK, FK"},
+		{ColumnInfo{Name: "Name", JSONName: "name", Type: "string"}, "id", ""},
+	}
+	for _, tt := range tests {
+		if got := columnKeys(tt.col, tt.pk); got != tt.want {
+			t.Errorf("columnKeys(%s, pk %s) = %q, want %q", tt.col.JSONName, tt.pk, got, tt.want)
+		}
+	}
+}
+
+func TestSelfReferenceTree(t *testing.T) {
+	schema := parseTestModels(t, map[string]string{
+		"internal/model/do/category.go": `package do
+
+type Category struct {
+	Id       uint        'json:"id"'
+	Pid      uint        'json:"pid"'
+	Name     string      'json:"name"'
+	Parent   *Category   'orm:"with:id=pid"'
+	Children []*Category 'orm:"with:pid=id"'
+	Posts    []*Post     'orm:"with:category_id=id"'
+}
+`,
+	})
+	meta := schema["Category"]
+	if meta == nil {
+		t.Fatal("Category not parsed")
+	}
+	want := map[string]struct {
+		self, collection bool
+		target, source   string
+	}{
+		"Parent":   {true, false, "id", "pid"},
+		"Children": {true, true, "pid", "id"},
+		"Posts":    {false, true, "category_id", "id"},
+	}
+	if len(meta.Relations) != len(want) {
+		t.Fatalf("got %d relations, want %d", len(meta.Relations), len(want))
+	}
+	for _, rel := range meta.Relations {
+		w, ok := want[rel.FieldName]
+		if !ok {
+			t.Errorf("unexpected relation %s", rel.FieldName)
+			continue
+		}
+		if rel.IsSelfReference != w.self || rel.IsCollection != w.collection ||
+			rel.TargetKey != w.target || rel.SourceKey != w.source {
+			t.Errorf("%s: got self=%v collection=%v %s=%s, want self=%v collection=%v %s=%s",
+				rel.FieldName, rel.IsSelfReference, rel.IsCollection, rel.TargetKey, rel.SourceKey,
+				w.self, w.collection, w.target, w.source)
+		}
+	}
+
+	diagram := generateERDiagram(schema)
+	for _, line := range []string{
+		`Category ||--o{ Category : "Children (pid=id, self)"`,
+		`Category ||--|| Category : "Parent (id=pid, self)"`,
+		`Category ||--o{ Post : "Posts (category_id=id)"`,
+	} {
+		if !strings.Contains(diagram, line) {
+			t.Errorf("ER diagram lacks %q", line)
+		}
+	}
+}
+
+// The with tags of GoFrame's mysql_z_unit_feature_with_test.go, plus the
+// many-to-many chains built on them.
+func TestParseWithTag(t *testing.T) {
+	tests := []struct {
+		tag            string
+		target, source string
+		through        string
+		keys           []RelationKeys
+	}{
+		{tag: "with:uid=id", target: "uid", source: "id"},
+		{tag: "with:uid", target: "uid", source: "id"},
+		{tag: "with: uid = id ", target: "uid", source: "id"},
+		{tag: "with:uid=id, where:score>1 and score<5, order:score desc", target: "uid", source: "id"},
+		{tag: "table:user_scores, with:uid=id", target: "uid", source: "id"},
+		{
+			tag:    "with:user_tags.user_id=id, with:id=user_tags.tag_id",
+			target: "user_id", source: "id", through: "user_tags",
+			keys: []RelationKeys{{TargetKey: "user_id", SourceKey: "id"}, {TargetKey: "id", SourceKey: "tag_id"}},
+		},
+		{
+			tag:    "table:user_tags, with:user_id=id, with:id=tag_id",
+			target: "user_id", source: "id", through: "user_tags",
+			keys: []RelationKeys{{TargetKey: "user_id", SourceKey: "id"}, {TargetKey: "id", SourceKey: "tag_id"}},
+		},
+	}
+	for _, tt := range tests {
+		rel := parseWithTag(tt.tag)
+		if rel == nil {
+			t.Errorf("%q: no relation", tt.tag)
+			continue
+		}
+		if rel.TargetKey != tt.target || rel.SourceKey != tt.source || rel.ThroughTable != tt.through ||
+			!slices.Equal(rel.ThroughKeys, tt.keys) {
+			t.Errorf("%q: got %s=%s via %q %v, want %s=%s via %q %v", tt.tag,
+				rel.TargetKey, rel.SourceKey, rel.ThroughTable, rel.ThroughKeys,
+				tt.target, tt.source, tt.through, tt.keys)
+		}
+	}
+	if rel := parseWithTag("table:user"); rel != nil {
+		t.Errorf(`"table:user": got %+v, want no relation`, rel)
+	}
+}
+
+func TestWithFeatureModels(t *testing.T) {
+	schema := parseTestModels(t, map[string]string{
+		"internal/model/do/user.go": `package do
+
+import "github.com/gogf/gf/v2/util/gmeta"
+
+type UserDetail struct {
+	gmeta.Meta 'orm:"table:user_detail"'
+	Uid        int    'json:"uid"'
+	Address    string 'json:"address"'
+}
+
+type UserScores struct {
+	gmeta.Meta 'orm:"table:user_scores"'
+	Id         int 'json:"id"'
+	Uid        int 'json:"uid"'
+	Score      int 'json:"score"'
+}
+
+type User struct {
+	gmeta.Meta 'orm:"table:user"'
+	Id         int           'json:"id"'
+	Name       string        'json:"name"'
+	UserDetail *UserDetail   'orm:"with:uid=id"'
+	UserScores []*UserScores 'orm:"with:uid=id"'
+	Tags       []*Tag        'orm:"with:user_tags.user_id=id, with:id=user_tags.tag_id"'
+}
+`,
+	})
+	user := schema["User"]
+	if user == nil {
+		t.Fatal("User not parsed")
+	}
+	var cols []string
+	for _, c := range user.Columns {
+		cols = append(cols, columnJSONName(c))
+	}
+	if !slices.Equal(cols, []string{"id", "name"}) {
+		t.Errorf("User columns = %v, want [id name] (gmeta.Meta and relations are not columns)", cols)
+	}
+	want := []struct {
+		field, target string
+		collection    bool
+		through       string
+	}{
+		{"UserDetail", "UserDetail", false, ""},
+		{"UserScores", "UserScores", true, ""},
+		{"Tags", "Tag", true, "user_tags"},
+	}
+	if len(user.Relations) != len(want) {
+		t.Fatalf("got %d relations, want %d", len(user.Relations), len(want))
+	}
+	for i, w := range want {
+		rel := user.Relations[i]
+		if rel.FieldName != w.field || rel.TargetStruct != w.target || rel.IsCollection != w.collection ||
+			rel.ThroughTable != w.through {
+			t.Errorf("relation %d: got %s -> %s collection=%v via %q, want %s -> %s collection=%v via %q", i,
+				rel.FieldName, rel.TargetStruct, rel.IsCollection, rel.ThroughTable,
+				w.field, w.target, w.collection, w.through)
+		}
+	}
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	schema := parseTestModels(t, map[string]string{
+		"internal/model/do/base.go": `package do
+
+import "github.com/gogf/gf/v2/frame/g"
+
+type Timestamps struct {
+	CreatedAt *gtime.Time 'json:"created_at"'
+	UpdatedAt *gtime.Time 'json:"updated_at"'
+}
+
+type Base struct {
+	Id uint 'json:"id"'
+	Timestamps
+}
+
+type User struct {
+	g.Meta 'orm:"table:user, do:true"'
+	Base
+	Name string 'json:"name"'
+	*Extra
+	gdb.Model
+}
+
+type Post struct {
+	Id    uint   'json:"id"'
+	Title string 'json:"title"'
+	Timestamps
+}
+`,
+		"internal/model/do/extra.go": `package do
+
+type Extra struct {
+	Note string 'json:"note"'
+}
+
+type Profile struct {
+	Uid uint    'json:"uid"'
+	Bio string  'json:"bio"'
+	Who *Extra  'orm:"with:id=uid"'
+}
+`,
+	})
+	tests := []struct {
+		entity string
+		cols   []string
+	}{
+		{"User", []string{"id", "created_at", "updated_at", "name", "note"}},
+		{"Post", []string{"id", "title", "created_at", "updated_at"}},
+	}
+	for _, tt := range tests {
+		meta := schema[tt.entity]
+		if meta == nil {
+			t.Errorf("%s not parsed", tt.entity)
+			continue
+		}
+		var cols []string
+		for _, c := range meta.Columns {
+			cols = append(cols, columnJSONName(c))
+		}
+		if !slices.Equal(cols, tt.cols) {
+			t.Errorf("%s columns = %v, want %v", tt.entity, cols, tt.cols)
+		}
+	}
+	for _, name := range []string{"Timestamps", "Base"} {
+		if schema[name] != nil {
+			t.Errorf("embedded-only %s is kept as an entity", name)
+		}
+	}
+	if schema["Extra"] == nil {
+		t.Error("Extra is a relation target and must stay an entity")
+	}
+}
+
+func TestGoEnums(t *testing.T) {
+	schema := parseTestModels(t, map[string]string{
+		"internal/consts/status.go": `package consts
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+)
+
+type Level int
+
+const (
+	LevelLow Level = iota
+	LevelMid
+	LevelHigh
+)
+`,
+		"internal/consts/order/status.go": `package order
+
+type Status string
+
+const (
+	Paid    Status = "paid"
+	Shipped Status = "shipped"
+)
+`,
+		"internal/model/do/user.go": `package do
+
+type Kind uint8
+
+const (
+	KindPerson Kind = iota + 1
+	KindRobot
+)
+
+type User struct {
+	Id          uint          'json:"id"'
+	Status      consts.Status 'json:"status"'
+	Level       consts.Level  'json:"level"'
+	OrderStatus order.Status  'json:"order_status"'
+	Kind        Kind          'json:"kind"'
+	Levels      []consts.Level 'json:"levels"'
+}
+`,
+	})
+	user := schema["User"]
+	if user == nil {
+		t.Fatal("User not parsed")
+	}
+	tests := []struct {
+		name         string
+		typ          string
+		enum, labels []string
+	}{
+		{"status", "consts.Status", []string{"active", "inactive"}, nil},
+		{"level", "int", []string{"0", "1", "2"}, []string{"Low", "Mid", "High"}},
+		{"order_status", "order.Status", []string{"paid", "shipped"}, nil},
+		{"kind", "uint8", []string{"1", "2"}, []string{"Person", "Robot"}},
+		{"levels", "consts.Level", nil, nil},
+	}
+	for _, tt := range tests {
+		col := findColumn(user, tt.name)
+		if col == nil {
+			t.Errorf("%s: no column", tt.name)
+			continue
+		}
+		var enum, labels []string
+		if col.Constraints != nil {
+			enum, labels = col.Constraints.Enum, col.Constraints.EnumLabels
+		}
+		if col.Type != tt.typ || !slices.Equal(enum, tt.enum) || !slices.Equal(labels, tt.labels) {
+			t.Errorf("%s: got %s %v %v, want %s %v %v", tt.name, col.Type, enum, labels, tt.typ, tt.enum, tt.labels)
+		}
+	}
+}
+
+func TestJSONSkippedFields(t *testing.T) {
+	schema := parseTestModels(t, map[string]string{
+		"internal/model/do/user.go": `package do
+
+type User struct {
+	Id       uint   'json:"id"'
+	Password string 'json:"-"'
+	Salt     string 'json:"-" v:"required"'
+	Email    string 'json:"email,omitempty"'
+	Nickname string
+}
+`,
+	})
+	user := schema["User"]
+	if user == nil {
+		t.Fatal("User not parsed")
+	}
+	var cols []string
+	for _, c := range user.Columns {
+		cols = append(cols, columnJSONName(c))
+	}
+	if want := []string{"id", "email", "Nickname"}; !slices.Equal(cols, want) {
+		t.Errorf("User columns = %v, want %v", cols, want)
+	}
+}
+
+func TestOpenAPISourceCache(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	t.Setenv("HOME", cacheHome)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"openapi": "3.0.0", "token": %q}`, r.Header.Get("Authorization"))
+	}))
+	url := srv.URL + "/openapi.json"
+
+	for _, token := range []string{"a", "b"} {
+		if _, err := readOpenAPISource(url, token); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cache, err := openAPICachePath(url, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := openAPICachePath(url, "b"); other == cache {
+		t.Error("specs fetched with different tokens share a cache file")
+	}
+	for path, want := range map[string]os.FileMode{filepath.Dir(cache): 0o700, cache: 0o600} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s: mode %o, want %o", path, got, want)
+		}
+	}
+
+	srv.Close()
+	b, err := readOpenAPISource(url, "a")
+	if err != nil {
+		t.Fatalf("offline read: %v", err)
+	}
+	if !strings.Contains(string(b), `"Bearer a"`) {
+		t.Errorf("offline read served %s, want the spec fetched with token a", b)
+	}
+}